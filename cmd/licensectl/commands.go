@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"license-service/internal/application/dto"
+	database "license-service/internal/persistence/configuration"
+	errorInfo "license-service/pkg/log/error"
+)
+
+func newFlagSet(cli *cli, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(cli.stderr)
+	return fs
+}
+
+func runMigrate(ctx context.Context, cli *cli, args []string) error {
+	if err := newFlagSet(cli, "migrate").Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+
+	c, err := cli.container(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.close(c)
+
+	if err := database.RunMigrations(c.DB); err != nil {
+		return errorInfo.WrapError(errorInfo.ErrDBMigration, "licensectl", "migrate", "failed to apply migrations", err)
+	}
+	cli.printer.Message("migrations applied")
+	return nil
+}
+
+func runIssue(ctx context.Context, cli *cli, args []string) error {
+	fs := newFlagSet(cli, "issue")
+	file := fs.String("file", "", "JSON file with the license (same body as POST /licenses)")
+	if err := fs.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	if *file == "" {
+		return missingFlag("file")
+	}
+
+	var reader io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return errorInfo.WrapError(errorInfo.ErrInvalidData, "licensectl", "issue", "cannot open "+*file, err)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	var request dto.CreateLicenseDTO
+	if err := json.NewDecoder(reader).Decode(&request); err != nil {
+		return errorInfo.WrapError(errorInfo.ErrInvalidFormat, "licensectl", "issue", "invalid license JSON", err)
+	}
+
+	c, err := cli.container(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.close(c)
+
+	license, err := c.UseCases.Licenses.Issuer.Execute(ctx, request)
+	if err != nil {
+		return err
+	}
+	cli.printer.License(license)
+	return nil
+}
+
+func runImport(ctx context.Context, cli *cli, args []string) error {
+	fs := newFlagSet(cli, "import")
+	file := fs.String("file", "", "CSV or NDJSON file with one license per row (\"-\" reads stdin)")
+	format := fs.String("format", "", "csv or ndjson, defaults to the file extension")
+	dryRun := fs.Bool("dry-run", false, "validate every row without saving anything")
+	chunkSize := fs.Int("chunk-size", 0, "rows per transaction, defaults to IMPORT_CHUNK_SIZE")
+	if err := fs.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	if *file == "" {
+		return missingFlag("file")
+	}
+	if *format == "" {
+		*format = importFormatFromExtension(*file)
+	}
+	if *format == "" {
+		return usageError("--format is required when the file extension is not .csv, .ndjson or .jsonl")
+	}
+	if *chunkSize < 0 {
+		return usageError("--chunk-size must be greater than 0")
+	}
+
+	var reader io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return errorInfo.WrapError(errorInfo.ErrInvalidData, "licensectl", "import", "cannot open "+*file, err)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	c, err := cli.container(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.close(c)
+
+	report, err := c.UseCases.Licenses.Importer.Execute(ctx, reader, dto.ImportOptionsDTO{
+		Format:    *format,
+		DryRun:    *dryRun,
+		ChunkSize: *chunkSize,
+	})
+	// Si la lectura se cortó, el informe parcial dice qué alcanzó a guardarse
+	// y se imprime antes del error.
+	if report != nil {
+		cli.printer.Import(report)
+	}
+	if err != nil {
+		return err
+	}
+
+	// El informe ya se imprimió; el error solo fija el código de salida para
+	// que un script note las filas rechazadas.
+	if report.Failed > 0 {
+		return errorInfo.NewAppError(errorInfo.ErrInvalidData, "licensectl", "import", fmt.Sprintf("%d of %d rows failed", report.Failed, report.Total))
+	}
+	return nil
+}
+
+func importFormatFromExtension(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".csv":
+		return dto.ImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return dto.ImportFormatNDJSON
+	}
+	return ""
+}
+
+func runRevoke(ctx context.Context, cli *cli, args []string) error {
+	fs := newFlagSet(cli, "revoke")
+	folio := fs.String("folio", "", "folio of the license to revoke")
+	if err := fs.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	if *folio == "" {
+		return missingFlag("folio")
+	}
+
+	c, err := cli.container(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.close(c)
+
+	license, err := c.UseCases.LicenseRevoker.Execute(ctx, *folio)
+	if err != nil {
+		return err
+	}
+	cli.printer.License(license)
+	return nil
+}
+
+func runExpire(ctx context.Context, cli *cli, args []string) error {
+	fs := newFlagSet(cli, "expire")
+	asOf := fs.String("as-of", "", "reference date (YYYY-MM-DD), defaults to today")
+	if err := fs.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+
+	date := time.Now()
+	if *asOf != "" {
+		parsed, err := time.Parse(dto.DateFormat, *asOf)
+		if err != nil {
+			return usageError("--as-of must use the YYYY-MM-DD format")
+		}
+		date = parsed
+	}
+
+	c, err := cli.container(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.close(c)
+
+	sweep, err := c.UseCases.LicenseExpirer.Execute(ctx, date)
+	if err != nil {
+		return err
+	}
+	cli.printer.Sweep(sweep)
+	return nil
+}
+
+func runExport(ctx context.Context, cli *cli, args []string) error {
+	fs := newFlagSet(cli, "export")
+	patient := fs.String("patient", "", "patient RUT")
+	licenseType := fs.String("type", "", "only licenses of this type")
+	format := fs.String("format", "", "csv, ndjson or parquet: write a bulk export file instead of printing")
+	columns := fs.String("columns", "", "comma-separated columns of the export file, defaults to all")
+	pseudonymize := fs.Bool("pseudonymize", false, "replace the diagnosis with a keyed pseudonym (EXPORT_PSEUDONYM_KEY)")
+	out := fs.String("out", "-", "export file (\"-\" writes stdout)")
+	if err := fs.Parse(args); err != nil {
+		return usageError(err.Error())
+	}
+	if *format != "" {
+		return runBulkExport(ctx, cli, dto.ExportRequestDTO{
+			Filter:                dto.LicenseFilterDTO{PatientID: *patient, Type: *licenseType},
+			Format:                *format,
+			Columns:               splitColumns(*columns),
+			PseudonymizeDiagnosis: *pseudonymize,
+		}, *out)
+	}
+	if *patient == "" {
+		return missingFlag("patient")
+	}
+
+	c, err := cli.container(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.close(c)
+
+	licenses, err := c.UseCases.Licenses.ByPatientRetriever.Execute(ctx, dto.LicenseFilterDTO{
+		PatientID: *patient,
+		Type:      *licenseType,
+	})
+	if err != nil {
+		return err
+	}
+	cli.printer.Licenses(licenses)
+	return nil
+}
+
+// runBulkExport escribe el archivo a medida que lee las licencias. Si falla a
+// mitad de camino, el archivo parcial se borra.
+func runBulkExport(ctx context.Context, cli *cli, request dto.ExportRequestDTO, out string) error {
+	c, err := cli.container(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.close(c)
+
+	if out == "-" {
+		_, err := c.UseCases.Licenses.Exporter.Execute(ctx, request, cli.printer.stdout)
+		return err
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return errorInfo.WrapError(errorInfo.ErrInvalidData, "licensectl", "export", "cannot create "+out, err)
+	}
+	rows, err := c.UseCases.Licenses.Exporter.Execute(ctx, request, f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = errorInfo.WrapError(errorInfo.ErrInternalError, "licensectl", "export", "cannot write "+out, closeErr)
+	}
+	if err != nil {
+		os.Remove(out)
+		return err
+	}
+	cli.printer.Message("%d licenses exported to %s", rows, out)
+	return nil
+}
+
+func splitColumns(value string) []string {
+	if value == "" {
+		return nil
+	}
+	columns := strings.Split(value, ",")
+	for i, column := range columns {
+		columns[i] = strings.TrimSpace(column)
+	}
+	return columns
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+
+	errorInfo "license-service/pkg/log/error"
+)
+
+// Códigos de salida de licensectl. Se derivan del AppError más interno de la
+// cadena, que es la causa real del fallo.
+const (
+	exitOK          = 0
+	exitInternal    = 1
+	exitUsage       = 2
+	exitNotFound    = 3
+	exitInvalidData = 4
+	exitConflict    = 5
+	exitForbidden   = 6
+	exitUnavailable = 7
+	exitConfig      = 8
+)
+
+var exitCodes = map[errorInfo.ErrorCode]int{
+	errorInfo.ErrNotFound:             exitNotFound,
+	errorInfo.ErrDBNotFound:           exitNotFound,
+	errorInfo.ErrInvalidData:          exitInvalidData,
+	errorInfo.ErrMissingRequiredField: exitInvalidData,
+	errorInfo.ErrValidationFailed:     exitInvalidData,
+	errorInfo.ErrInvalidFormat:        exitInvalidData,
+	errorInfo.ErrValueOutOfRange:      exitInvalidData,
+	errorInfo.ErrConflict:             exitConflict,
+	errorInfo.ErrDBConflict:           exitConflict,
+	errorInfo.ErrAlreadyExists:        exitConflict,
+	errorInfo.ErrUnauthorized:         exitForbidden,
+	errorInfo.ErrForbidden:            exitForbidden,
+	errorInfo.ErrDBConnection:         exitUnavailable,
+	errorInfo.ErrDBTimeout:            exitUnavailable,
+	errorInfo.ErrServiceUnavailable:   exitUnavailable,
+	errorInfo.ErrCircuitBreakerOpen:   exitUnavailable,
+	errorInfo.ErrConfigLoadFailed:     exitConfig,
+	errorInfo.ErrServiceConfig:        exitConfig,
+}
+
+func exitCode(err error) int {
+	root := rootAppError(err)
+	if root == nil {
+		return exitInternal
+	}
+	if exit, ok := exitCodes[root.Code]; ok {
+		return exit
+	}
+	return exitInternal
+}
+
+func rootAppError(err error) *errorInfo.AppError {
+	var root *errorInfo.AppError
+	for err != nil {
+		var appErr *errorInfo.AppError
+		if !errors.As(err, &appErr) {
+			break
+		}
+		root = appErr
+		err = appErr.Cause
+	}
+	return root
+}
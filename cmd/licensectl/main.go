@@ -0,0 +1,172 @@
+// licensectl ejecuta tareas de operación sobre el servicio de licencias usando
+// la misma configuración y los mismos casos de uso que la API.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"license-service/internal/bootstrap"
+	"license-service/pkg/env"
+	logs "license-service/pkg/log/logger"
+)
+
+const usage = `Usage: licensectl [--config FILE] [--output human|json] [--verbose] <command> [options]
+
+Commands:
+  migrate                      apply database migrations
+  issue --file FILE            issue a license from a JSON file ("-" reads stdin)
+  import --file FILE [--format csv|ndjson] [--dry-run] [--chunk-size N]
+                               bulk-load licenses, one per row, and print a per-row report
+  revoke --folio FOLIO         revoke an issued license
+  expire [--as-of YYYY-MM-DD]  mark issued licenses whose rest period ended as expired
+  export --patient RUT [--type TYPE]
+                               print every license of a patient
+  export --format csv|ndjson|parquet [--patient RUT] [--type TYPE]
+         [--columns A,B] [--pseudonymize] [--out FILE]
+                               write a bulk export file, stdout by default
+`
+
+type command struct {
+	name string
+	run  func(ctx context.Context, cli *cli, args []string) error
+}
+
+var commands = []command{
+	{name: "migrate", run: runMigrate},
+	{name: "issue", run: runIssue},
+	{name: "import", run: runImport},
+	{name: "revoke", run: runRevoke},
+	{name: "expire", run: runExpire},
+	{name: "export", run: runExport},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	global := flag.NewFlagSet("licensectl", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.Usage = func() { fmt.Fprint(stderr, usage) }
+
+	configFile := global.String("config", "", "path to a YAML or JSON config file")
+	output := global.String("output", outputHuman, "output format: human or json")
+	verbose := global.Bool("verbose", false, "write service logs to stderr")
+
+	if err := global.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *output != outputHuman && *output != outputJSON {
+		fmt.Fprintln(stderr, "--output must be human or json")
+		return exitUsage
+	}
+
+	rest := global.Args()
+	if len(rest) == 0 {
+		global.Usage()
+		return exitUsage
+	}
+
+	var selected *command
+	for i := range commands {
+		if commands[i].name == rest[0] {
+			selected = &commands[i]
+		}
+	}
+	if selected == nil {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", rest[0], usage)
+		return exitUsage
+	}
+
+	cli := &cli{
+		printer: newPrinter(*output, stdout, stderr),
+		verbose: *verbose,
+		stderr:  stderr,
+	}
+
+	// env.Load registra avisos antes de que exista el contenedor; no deben
+	// mezclarse con la salida de los comandos.
+	logs.Configure(logs.Options{Level: "warn", Format: logs.FormatText, Output: stderr})
+
+	var envArgs []string
+	if *configFile != "" {
+		envArgs = []string{"--config", *configFile}
+	}
+	config, err := env.Load(envArgs)
+	if err != nil {
+		cli.printer.Error(err)
+		return exitCode(err)
+	}
+	cli.config = config
+
+	if err := selected.run(ctx, cli, rest[1:]); err != nil {
+		if _, usageErr := err.(usageError); usageErr {
+			fmt.Fprintf(stderr, "%v\n\n%s", err, usage)
+			return exitUsage
+		}
+		cli.printer.Error(err)
+		return exitCode(err)
+	}
+	return exitOK
+}
+
+type cli struct {
+	config  *env.Config
+	printer *printer
+	verbose bool
+	stderr  io.Writer
+}
+
+// container arma el grafo de la aplicación sin la capa HTTP. Las migraciones
+// solo se aplican desde el comando migrate.
+func (cli *cli) container(ctx context.Context) (*bootstrap.Container, error) {
+	config := *cli.config
+	config.Database.AutoMigrate = false
+	config.Tracing.Enabled = false
+	if !cli.verbose {
+		config.App.LogLevel = "warn"
+		config.Database.GormLogLevel = "silent"
+	}
+
+	c := bootstrap.NewContainer(&config)
+	c.LogOutput = cli.stderr
+
+	err := bootstrap.Build(c,
+		bootstrap.ObservabilityModule,
+		bootstrap.DatabaseModule,
+		bootstrap.RepositoryModule,
+		bootstrap.UseCaseModule,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Lifecycle.Start(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (cli *cli) close(c *bootstrap.Container) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Config.Server.ShutdownTimeout)
+	defer cancel()
+	if err := c.Lifecycle.Stop(ctx); err != nil {
+		c.Logger.Error("licensectl", "close", err)
+	}
+}
+
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+func missingFlag(name string) error {
+	return usageError("--" + name + " is required")
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"license-service/internal/application/dto"
+	errorInfo "license-service/pkg/log/error"
+)
+
+const (
+	outputHuman = "human"
+	outputJSON  = "json"
+)
+
+type printer struct {
+	format string
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func newPrinter(format string, stdout, stderr io.Writer) *printer {
+	return &printer{format: format, stdout: stdout, stderr: stderr}
+}
+
+func (p *printer) JSON(value any) {
+	encoder := json.NewEncoder(p.stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(value)
+}
+
+func (p *printer) Message(format string, args ...any) {
+	if p.format == outputJSON {
+		p.JSON(map[string]string{"message": fmt.Sprintf(format, args...)})
+		return
+	}
+	fmt.Fprintf(p.stdout, format+"\n", args...)
+}
+
+func (p *printer) License(license *dto.LicenseDTO) {
+	if p.format == outputJSON {
+		p.JSON(license)
+		return
+	}
+	p.Licenses([]*dto.LicenseDTO{license})
+}
+
+func (p *printer) Licenses(licenses []*dto.LicenseDTO) {
+	if p.format == outputJSON {
+		p.JSON(licenses)
+		return
+	}
+
+	table := tabwriter.NewWriter(p.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "FOLIO\tTYPE\tPATIENT\tDOCTOR\tSTART\tEND\tDAYS\tSTATUS")
+	for _, license := range licenses {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			license.Folio, license.Type, license.PatientID, license.DoctorID, license.StartDate, license.EndDate, license.Days, license.Status)
+	}
+	table.Flush()
+}
+
+func (p *printer) Sweep(sweep *dto.ExpirationSweepDTO) {
+	if p.format == outputJSON {
+		p.JSON(sweep)
+		return
+	}
+	fmt.Fprintf(p.stdout, "expired %d licenses ending before %s\n", sweep.Expired, sweep.AsOf)
+	if len(sweep.Folios) > 0 {
+		fmt.Fprintln(p.stdout, strings.Join(sweep.Folios, "\n"))
+	}
+}
+
+func (p *printer) Import(report *dto.ImportReportDTO) {
+	if p.format == outputJSON {
+		p.JSON(report)
+		return
+	}
+
+	table := tabwriter.NewWriter(p.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ROW\tSTATUS\tFOLIO\tERROR")
+	for _, row := range report.Rows {
+		message := ""
+		if row.Error != nil {
+			message = row.Error.Code + ": " + row.Error.Message
+		}
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", row.Row, row.Status, row.Folio, message)
+	}
+	table.Flush()
+
+	if report.Interrupted {
+		fmt.Fprintf(p.stdout, "import interrupted after %d rows: %d created, %d valid, %d failed, %d not processed; the rest of the file was not read\n",
+			report.Total, report.Created, report.Valid, report.Failed, report.NotProcessed)
+		return
+	}
+	if report.DryRun {
+		fmt.Fprintf(p.stdout, "dry run: %d valid, %d failed of %d rows, nothing was saved\n", report.Valid, report.Failed, report.Total)
+		return
+	}
+	fmt.Fprintf(p.stdout, "created %d, failed %d of %d rows\n", report.Created, report.Failed, report.Total)
+}
+
+// Error escribe el fallo en stderr; en modo json usa el mismo cuerpo que la API
+// con el código de la causa raíz.
+func (p *printer) Error(err error) {
+	if p.format == outputJSON {
+		body := errorInfo.Error{Code: string(errorInfo.ErrInternalError), Message: err.Error()}
+		if root := rootAppError(err); root != nil {
+			body = root.ToErrorDTO()
+			if root != err {
+				body.Details = err.Error()
+			}
+		}
+		encoder := json.NewEncoder(p.stderr)
+		encoder.Encode(map[string]errorInfo.Error{"error": body})
+		return
+	}
+	fmt.Fprintln(p.stderr, "error:", err)
+}
@@ -0,0 +1,14 @@
+package dto
+
+// AmendLicenseDTO es el body de POST /licenses/{folio}/amendments. Solo se
+// enmiendan los campos presentes; patientId se acepta únicamente para rechazar
+// un cambio de paciente y doctorId solo se usa con la autenticación deshabilitada.
+type AmendLicenseDTO struct {
+	PatientID    string  `json:"patientId,omitempty"`
+	DoctorID     string  `json:"doctorId,omitempty"`
+	Diagnosis    *string `json:"diagnosis,omitempty"`
+	RestType     *string `json:"restType,omitempty"`
+	RestLocation *string `json:"restLocation,omitempty"`
+	RestAddress  *string `json:"restAddress,omitempty"`
+	Reason       string  `json:"reason"`
+}
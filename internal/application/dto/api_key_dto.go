@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type CreateAPIKeyDTO struct {
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	OwnerRut  string     `json:"ownerRut"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+type APIKeyDTO struct {
+	Prefix     string     `json:"prefix"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	OwnerRut   string     `json:"ownerRut,omitempty"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	UsageCount int64      `json:"usageCount"`
+}
+
+// IssuedAPIKeyDTO es la única respuesta que contiene la clave en claro; no se
+// puede volver a obtener después.
+type IssuedAPIKeyDTO struct {
+	APIKeyDTO
+	Key string `json:"key"`
+}
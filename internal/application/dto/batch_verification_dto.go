@@ -0,0 +1,37 @@
+package dto
+
+// BatchVerificationRequestDTO es el body de POST /licenses/verify:batch.
+type BatchVerificationRequestDTO struct {
+	Items []BatchVerificationItemDTO `json:"items"`
+	// RequirePatientID lo fija la política de acceso: sin PatientID el ítem se
+	// rechaza en vez de verificarse.
+	RequirePatientID bool `json:"-"`
+}
+
+// BatchVerificationItemDTO pide verificar un folio. Con PatientID la licencia
+// solo se informa si es de ese paciente; si no, el resultado es not_found, así
+// el lote no sirve para enumerar folios.
+type BatchVerificationItemDTO struct {
+	Folio     string `json:"folio"`
+	PatientID string `json:"patientId,omitempty"`
+}
+
+// BatchVerificationDTO trae un resultado por ítem, en el mismo orden del pedido.
+type BatchVerificationDTO struct {
+	Results   []BatchVerificationResultDTO `json:"results"`
+	CheckedAt string                       `json:"checkedAt"`
+}
+
+// BatchVerificationResultDTO lleva la verificación del folio o, si el ítem era
+// inválido, su error.
+type BatchVerificationResultDTO struct {
+	Folio        string                  `json:"folio"`
+	Verification *LicenseVerificationDTO `json:"verification,omitempty"`
+	Error        *ItemErrorDTO           `json:"error,omitempty"`
+}
+
+// ItemErrorDTO es el error de un ítem dentro de una operación por lotes.
+type ItemErrorDTO struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
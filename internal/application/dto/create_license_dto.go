@@ -6,11 +6,23 @@ import (
 )
 
 type CreateLicenseDTO struct {
-	PatientID string     `json:"patientId" validate:"required"`
-	DoctorID  string     `json:"doctorId" validate:"required"`
-	Diagnosis string     `json:"diagnosis" validate:"required"`
-	StartDate CustomDate `json:"startDate" validate:"required"`
-	Days      uint8      `json:"days" validate:"required,gt=0"`
+	PatientID string      `json:"patientId" validate:"required"`
+	DoctorID  string      `json:"doctorId" validate:"required"`
+	Diagnosis string      `json:"diagnosis" validate:"required"`
+	StartDate CustomDate  `json:"startDate" validate:"required"`
+	Days      uint8       `json:"days" validate:"required,gt=0"`
+	Type      string      `json:"type"`
+	DueDate   *CustomDate `json:"dueDate,omitempty"`
+	ChildRut  string      `json:"childRut,omitempty"`
+	// RestType es total (por defecto) o partial; RestLocation es home (por
+	// defecto), hospital u other, este último con RestAddress obligatoria.
+	RestType     string `json:"restType"`
+	RestLocation string `json:"restLocation"`
+	RestAddress  string `json:"restAddress,omitempty"`
+	// EmployerRuts son los empleadores a los que se entrega la licencia.
+	EmployerRuts []string `json:"employerRuts,omitempty"`
+	// InsurerRut es la aseguradora (FONASA o ISAPRE) que resolverá la licencia.
+	InsurerRut string `json:"insurerRut,omitempty"`
 }
 
 type CustomDate struct {
@@ -18,11 +30,24 @@ type CustomDate struct {
 }
 
 func (cd *CustomDate) UnmarshalJSON(b []byte) error {
-	s := string(b)
-	s = s[1 : len(s)-1]
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return &time.ParseError{Layout: DateFormat, Value: string(b)}
+	}
+
+	date, err := ParseCustomDate(s)
+	if err != nil {
+		return err
+	}
+	*cd = date
+	return nil
+}
 
+// ParseCustomDate acepta una fecha YYYY-MM-DD o un instante RFC 3339, igual
+// que el JSON de los pedidos.
+func ParseCustomDate(s string) (CustomDate, error) {
 	formats := []string{
-		"2006-01-02",
+		DateFormat,
 		"2006-01-02T15:04:05Z",
 		"2006-01-02T15:04:05Z07:00",
 		time.RFC3339,
@@ -30,14 +55,13 @@ func (cd *CustomDate) UnmarshalJSON(b []byte) error {
 
 	for _, format := range formats {
 		if t, err := time.Parse(format, s); err == nil {
-			cd.Time = t
-			return nil
+			return CustomDate{Time: t}, nil
 		}
 	}
 
-	return &time.ParseError{Layout: "2006-01-02", Value: s}
+	return CustomDate{}, &time.ParseError{Layout: DateFormat, Value: s}
 }
 
 func (cd CustomDate) MarshalJSON() ([]byte, error) {
-	return json.Marshal(cd.Time.Format("2006-01-02"))
+	return json.Marshal(cd.Time.Format(DateFormat))
 }
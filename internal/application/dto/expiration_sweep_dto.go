@@ -0,0 +1,7 @@
+package dto
+
+type ExpirationSweepDTO struct {
+	AsOf    string   `json:"asOf"`
+	Expired int      `json:"expired"`
+	Folios  []string `json:"folios"`
+}
@@ -0,0 +1,60 @@
+package dto
+
+import "io"
+
+// Formatos de GET /licenses/export.
+const (
+	ExportFormatCSV     = "csv"
+	ExportFormatNDJSON  = "ndjson"
+	ExportFormatParquet = "parquet"
+)
+
+// ExportContentType es el Content-Type de un archivo exportado en format.
+func ExportContentType(format string) string {
+	switch format {
+	case ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case ExportFormatNDJSON:
+		return "application/x-ndjson"
+	case ExportFormatParquet:
+		return "application/vnd.apache.parquet"
+	}
+	return "application/octet-stream"
+}
+
+// ExportRequestDTO es un pedido de exportación. Filter usa los mismos criterios
+// que GET /licenses, aunque sin paciente exporta todas las licencias. Sin
+// Columns se exportan todas las columnas.
+type ExportRequestDTO struct {
+	Filter                LicenseFilterDTO
+	Format                string
+	Columns               []string
+	PseudonymizeDiagnosis bool
+}
+
+// ExportJobDTO es el estado de una exportación asíncrona. Links.Download solo
+// aparece cuando el archivo está listo.
+type ExportJobDTO struct {
+	ID         string            `json:"id"`
+	Status     string            `json:"status"`
+	Format     string            `json:"format"`
+	Rows       int               `json:"rows"`
+	Error      *ItemErrorDTO     `json:"error,omitempty"`
+	CreatedAt  string            `json:"createdAt"`
+	FinishedAt string            `json:"finishedAt,omitempty"`
+	ExpiresAt  string            `json:"expiresAt,omitempty"`
+	Links      ExportJobLinksDTO `json:"links"`
+}
+
+type ExportJobLinksDTO struct {
+	Self     string `json:"self"`
+	Download string `json:"download,omitempty"`
+}
+
+// ExportFileDTO es el archivo de una exportación terminada; quien lo recibe
+// debe cerrar Content.
+type ExportFileDTO struct {
+	Name        string
+	ContentType string
+	Content     io.ReadCloser
+}
@@ -0,0 +1,58 @@
+package dto
+
+// Formatos que acepta una importación masiva.
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+)
+
+// Estado de cada fila en el informe de una importación. "valid" solo aparece en
+// un dry run: la fila pasó las validaciones pero no se guardó. "not_processed"
+// marca las filas leídas que esperaban su tramo cuando la importación se cortó.
+const (
+	ImportRowCreated      = "created"
+	ImportRowValid        = "valid"
+	ImportRowFailed       = "failed"
+	ImportRowNotProcessed = "not_processed"
+)
+
+// ImportOptionsDTO configura una importación. ChunkSize 0 usa el tamaño
+// configurado en el servicio.
+type ImportOptionsDTO struct {
+	Format    string
+	DryRun    bool
+	ChunkSize int
+}
+
+// ImportLicenseRowDTO es una fila del archivo: los campos de POST /licenses más
+// el folio en papel y la fecha de emisión original, ambos opcionales. Sin folio
+// se genera uno; sin issuedAt la licencia se emite en el momento de importarla.
+type ImportLicenseRowDTO struct {
+	CreateLicenseDTO
+	Folio    string      `json:"folio,omitempty"`
+	IssuedAt *CustomDate `json:"issuedAt,omitempty"`
+}
+
+// ImportReportDTO trae un resultado por fila, en el orden del archivo, y los
+// totales por estado. Si la lectura del archivo se cortó, Interrupted es true,
+// Error trae la causa y Rows llega solo hasta la última fila leída.
+type ImportReportDTO struct {
+	DryRun       bool                 `json:"dryRun"`
+	Interrupted  bool                 `json:"interrupted"`
+	Total        int                  `json:"total"`
+	Created      int                  `json:"created"`
+	Valid        int                  `json:"valid"`
+	Failed       int                  `json:"failed"`
+	NotProcessed int                  `json:"notProcessed"`
+	Error        *ItemErrorDTO        `json:"error,omitempty"`
+	Rows         []ImportRowResultDTO `json:"rows"`
+}
+
+// ImportRowResultDTO informa una fila; Row cuenta desde 1 sin el encabezado.
+// Folio es el folio creado o, en un dry run, el que traía la fila.
+type ImportRowResultDTO struct {
+	Row    int           `json:"row"`
+	Folio  string        `json:"folio,omitempty"`
+	Status string        `json:"status"`
+	Error  *ItemErrorDTO `json:"error,omitempty"`
+}
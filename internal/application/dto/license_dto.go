@@ -1,11 +1,49 @@
 package dto
 
+const DateFormat = "2006-01-02"
+
 type LicenseDTO struct {
-	Folio     string
-	PatientID string
-	DoctorID  string
-	Diagnosis string
-	StartDate string
-	Days      uint8
-	Status    string
+	Folio     string `json:"folio"`
+	PatientID string `json:"patientId"`
+	DoctorID  string `json:"doctorId"`
+	Diagnosis string `json:"diagnosis,omitempty"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	Days      uint8  `json:"days"`
+	// EffectiveDays descuenta los días de reposo parcial (media jornada).
+	EffectiveDays float64                `json:"effectiveDays"`
+	Status        string                 `json:"status"`
+	Type          string                 `json:"type"`
+	Payer         string                 `json:"payer"`
+	DueDate       string                 `json:"dueDate,omitempty"`
+	ChildRut      string                 `json:"childRut,omitempty"`
+	RestType      string                 `json:"restType"`
+	RestLocation  string                 `json:"restLocation"`
+	RestAddress   string                 `json:"restAddress,omitempty"`
+	Employers     []LicenseEmployerDTO   `json:"employers,omitempty"`
+	InsurerRut    string                 `json:"insurerRut,omitempty"`
+	Adjudication  LicenseAdjudicationDTO `json:"adjudication"`
+	Version       int                    `json:"version"`
+	Revision      int                    `json:"revision"`
+	IssuedAt      string                 `json:"issuedAt"`
+	Links         LicenseLinksDTO        `json:"links"`
+}
+
+type LicenseEmployerDTO struct {
+	Rut            string `json:"rut"`
+	AcknowledgedAt string `json:"acknowledgedAt,omitempty"`
+}
+
+type LicenseAdjudicationDTO struct {
+	Status                string   `json:"status"`
+	Reason                string   `json:"reason,omitempty"`
+	ApprovedDays          *uint8   `json:"approvedDays,omitempty"`
+	EffectiveApprovedDays *float64 `json:"effectiveApprovedDays,omitempty"`
+	ResolvedAt            string   `json:"resolvedAt,omitempty"`
+}
+
+type LicenseLinksDTO struct {
+	Self   string `json:"self"`
+	Verify string `json:"verify"`
+	Pdf    string `json:"pdf"`
 }
@@ -0,0 +1,15 @@
+package dto
+
+// LicenseFilterDTO son los filtros de GET /licenses; los vacíos no filtran.
+type LicenseFilterDTO struct {
+	PatientID string
+	Type      string
+}
+
+// EmployerLicenseFilterDTO son los filtros de GET /employers/{rut}/licenses.
+type EmployerLicenseFilterDTO struct {
+	EmployerRut string
+	Status      string
+	// Acknowledged filtra por la recepción del empleador; nil no filtra.
+	Acknowledged *bool
+}
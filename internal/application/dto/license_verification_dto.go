@@ -0,0 +1,24 @@
+package dto
+
+// LicenseVerificationDTO es la respuesta de GET /licenses/{folio}/verify. Reason
+// explica el resultado (valid, pending_review, not_started, expired, revoked,
+// rejected o not_found); para un folio inexistente solo se informan folio,
+// valid, reason y checkedAt.
+type LicenseVerificationDTO struct {
+	Folio                 string   `json:"folio"`
+	Valid                 bool     `json:"valid"`
+	Reason                string   `json:"reason"`
+	Status                string   `json:"status,omitempty"`
+	AdjudicationStatus    string   `json:"adjudicationStatus,omitempty"`
+	ValidFrom             string   `json:"validFrom,omitempty"`
+	ValidUntil            string   `json:"validUntil,omitempty"`
+	DaysRemaining         int      `json:"daysRemaining"`
+	ApprovedDays          *uint8   `json:"approvedDays,omitempty"`
+	EffectiveApprovedDays *float64 `json:"effectiveApprovedDays,omitempty"`
+	CheckedAt             string   `json:"checkedAt"`
+}
+
+// Found indica si el folio existe.
+func (v *LicenseVerificationDTO) Found() bool {
+	return v.Status != ""
+}
@@ -0,0 +1,16 @@
+package dto
+
+type LicenseVersionDTO struct {
+	Version   int              `json:"version"`
+	Author    string           `json:"author"`
+	Reason    string           `json:"reason"`
+	Changes   []FieldChangeDTO `json:"changes"`
+	CreatedAt string           `json:"createdAt"`
+	License   *LicenseDTO      `json:"license"`
+}
+
+type FieldChangeDTO struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// ResolutionDTO es el body de POST /licenses/{folio}/resolution. approvedDays
+// solo se informa cuando status es "reduced".
+type ResolutionDTO struct {
+	Status       string `json:"status"`
+	Reason       string `json:"reason,omitempty"`
+	ApprovedDays uint8  `json:"approvedDays,omitempty"`
+}
@@ -0,0 +1,29 @@
+package mapper
+
+import (
+	"license-service/internal/application/dto"
+	model "license-service/internal/domain/model"
+)
+
+func ToAPIKeyDTO(key *model.APIKey) *dto.APIKeyDTO {
+	return &dto.APIKeyDTO{
+		Prefix:     key.Prefix,
+		Name:       key.Name,
+		Role:       key.Role,
+		OwnerRut:   key.OwnerRut,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		UsageCount: key.UsageCount,
+	}
+}
+
+func ToAPIKeyDTOs(keys []*model.APIKey) []*dto.APIKeyDTO {
+	keyDTOs := make([]*dto.APIKeyDTO, 0, len(keys))
+	for _, key := range keys {
+		keyDTOs = append(keyDTOs, ToAPIKeyDTO(key))
+	}
+	return keyDTOs
+}
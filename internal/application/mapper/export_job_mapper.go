@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"time"
+
+	"license-service/internal/application/dto"
+	model "license-service/internal/domain/model"
+)
+
+func ToExportJobDTO(job *model.ExportJob) *dto.ExportJobDTO {
+	self := "/licenses/export/jobs/" + job.ID
+	jobDTO := &dto.ExportJobDTO{
+		ID:        job.ID,
+		Status:    job.Status,
+		Format:    job.Format,
+		Rows:      job.Rows,
+		CreatedAt: job.CreatedAt.UTC().Format(time.RFC3339),
+		Links:     dto.ExportJobLinksDTO{Self: self},
+	}
+	if job.FinishedAt != nil {
+		jobDTO.FinishedAt = job.FinishedAt.UTC().Format(time.RFC3339)
+	}
+	if job.ExpiresAt != nil {
+		jobDTO.ExpiresAt = job.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if job.Status == model.ExportJobSucceeded {
+		jobDTO.Links.Download = self + "/download"
+	}
+	if job.Status == model.ExportJobFailed {
+		jobDTO.Error = &dto.ItemErrorDTO{Code: job.ErrorCode, Message: job.ErrorMessage}
+	}
+	return jobDTO
+}
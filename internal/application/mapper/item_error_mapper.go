@@ -0,0 +1,18 @@
+package mapper
+
+import (
+	"errors"
+
+	"license-service/internal/application/dto"
+	errorInfo "license-service/pkg/log/error"
+)
+
+// ToItemErrorDTO expone el código y mensaje de un AppError para un ítem de un
+// lote. Cualquier otro error se informa como interno, sin detalles.
+func ToItemErrorDTO(err error) *dto.ItemErrorDTO {
+	var appErr *errorInfo.AppError
+	if errors.As(err, &appErr) {
+		return &dto.ItemErrorDTO{Code: string(appErr.Code), Message: appErr.Message}
+	}
+	return &dto.ItemErrorDTO{Code: string(errorInfo.ErrInternalError), Message: "unexpected error"}
+}
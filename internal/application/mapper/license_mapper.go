@@ -0,0 +1,162 @@
+package mapper
+
+import (
+	"time"
+
+	"license-service/internal/application/dto"
+	model "license-service/internal/domain/model"
+)
+
+// ToLicenseDTO es el único punto de conversión de una licencia de dominio a su
+// representación pública, para que POST y GET respondan con el mismo contrato.
+func ToLicenseDTO(license *model.License) *dto.LicenseDTO {
+	issuedAt := ""
+	if !license.IssuedAt.IsZero() {
+		issuedAt = license.IssuedAt.UTC().Format(time.RFC3339)
+	}
+
+	dueDate := ""
+	if license.DueDate != nil {
+		dueDate = license.DueDate.Format(dto.DateFormat)
+	}
+
+	return &dto.LicenseDTO{
+		Folio:         license.Folio,
+		PatientID:     license.PatientID,
+		DoctorID:      license.DoctorID,
+		Diagnosis:     license.Diagnosis,
+		StartDate:     license.StartDate.Format(dto.DateFormat),
+		EndDate:       license.EndDate().Format(dto.DateFormat),
+		Days:          license.Days,
+		EffectiveDays: license.EffectiveDays(),
+		Status:        license.Status,
+		Type:          license.Type,
+		Payer:         license.Payer,
+		DueDate:       dueDate,
+		ChildRut:      license.ChildRut,
+		RestType:      license.RestType,
+		RestLocation:  license.RestLocation,
+		RestAddress:   license.RestAddress,
+		Employers:     toLicenseEmployers(license.Employers),
+		InsurerRut:    license.InsurerRut,
+		Adjudication:  toLicenseAdjudication(license),
+		Version:       license.CurrentVersion(),
+		Revision:      license.CurrentRevision(),
+		IssuedAt:      issuedAt,
+		Links:         toLicenseLinks(license.Folio),
+	}
+}
+
+func ToLicenseDTOs(licenses []*model.License) []*dto.LicenseDTO {
+	licenseDTOs := make([]*dto.LicenseDTO, 0, len(licenses))
+	for _, license := range licenses {
+		licenseDTOs = append(licenseDTOs, ToLicenseDTO(license))
+	}
+	return licenseDTOs
+}
+
+func ToLicenseVersionDTO(version *model.LicenseVersion) *dto.LicenseVersionDTO {
+	changes := make([]dto.FieldChangeDTO, 0, len(version.Changes))
+	for _, change := range version.Changes {
+		changes = append(changes, dto.FieldChangeDTO(change))
+	}
+
+	createdAt := ""
+	if !version.CreatedAt.IsZero() {
+		createdAt = version.CreatedAt.UTC().Format(time.RFC3339)
+	}
+
+	return &dto.LicenseVersionDTO{
+		Version:   version.Version,
+		Author:    version.Author,
+		Reason:    version.Reason,
+		Changes:   changes,
+		CreatedAt: createdAt,
+		License:   ToLicenseDTO(&version.Snapshot),
+	}
+}
+
+func ToLicenseVersionDTOs(versions []*model.LicenseVersion) []*dto.LicenseVersionDTO {
+	versionDTOs := make([]*dto.LicenseVersionDTO, 0, len(versions))
+	for _, version := range versions {
+		versionDTOs = append(versionDTOs, ToLicenseVersionDTO(version))
+	}
+	return versionDTOs
+}
+
+func toLicenseEmployers(employers []model.Employer) []dto.LicenseEmployerDTO {
+	if len(employers) == 0 {
+		return nil
+	}
+
+	employerDTOs := make([]dto.LicenseEmployerDTO, 0, len(employers))
+	for _, employer := range employers {
+		acknowledgedAt := ""
+		if employer.AcknowledgedAt != nil {
+			acknowledgedAt = employer.AcknowledgedAt.UTC().Format(time.RFC3339)
+		}
+		employerDTOs = append(employerDTOs, dto.LicenseEmployerDTO{
+			Rut:            employer.Rut,
+			AcknowledgedAt: acknowledgedAt,
+		})
+	}
+	return employerDTOs
+}
+
+func toLicenseAdjudication(license *model.License) dto.LicenseAdjudicationDTO {
+	adjudication := dto.LicenseAdjudicationDTO{
+		Status:       license.Adjudication.Status,
+		Reason:       license.Adjudication.Reason,
+		ApprovedDays: license.Adjudication.ApprovedDays,
+	}
+	if license.Adjudication.ResolvedAt != nil {
+		adjudication.ResolvedAt = license.Adjudication.ResolvedAt.UTC().Format(time.RFC3339)
+	}
+	if effectiveDays, ok := license.EffectiveApprovedDays(); ok {
+		adjudication.EffectiveApprovedDays = &effectiveDays
+	}
+	return adjudication
+}
+
+// ToLicenseVerificationDTO resume el estado de una licencia al momento de la
+// verificación (now).
+func ToLicenseVerificationDTO(license *model.License, now time.Time) *dto.LicenseVerificationDTO {
+	reason := license.VerificationReason(now)
+	verification := &dto.LicenseVerificationDTO{
+		Folio:              license.Folio,
+		Valid:              model.IsValidReason(reason),
+		Reason:             reason,
+		Status:             license.Status,
+		AdjudicationStatus: license.Adjudication.Status,
+		ValidFrom:          license.StartDate.Format(dto.DateFormat),
+		ValidUntil:         license.ValidUntil().Format(dto.DateFormat),
+		ApprovedDays:       license.Adjudication.ApprovedDays,
+		CheckedAt:          now.UTC().Format(time.RFC3339),
+	}
+	if verification.Valid {
+		verification.DaysRemaining = license.DaysRemainingAt(now)
+	}
+	if effectiveDays, ok := license.EffectiveApprovedDays(); ok {
+		verification.EffectiveApprovedDays = &effectiveDays
+	}
+	return verification
+}
+
+// ToNotFoundVerificationDTO es la verificación de un folio que no existe.
+func ToNotFoundVerificationDTO(folio string, now time.Time) *dto.LicenseVerificationDTO {
+	return &dto.LicenseVerificationDTO{
+		Folio:     folio,
+		Valid:     false,
+		Reason:    model.VerificationNotFound,
+		CheckedAt: now.UTC().Format(time.RFC3339),
+	}
+}
+
+func toLicenseLinks(folio string) dto.LicenseLinksDTO {
+	self := "/licenses/" + folio
+	return dto.LicenseLinksDTO{
+		Self:   self,
+		Verify: self + "/verify",
+		Pdf:    self + "/pdf",
+	}
+}
@@ -0,0 +1,388 @@
+package policy
+
+import (
+	"context"
+	"io"
+
+	"license-service/internal/application/dto"
+	"license-service/internal/application/usecase/contrats"
+	"license-service/pkg/auth"
+	errorInfo "license-service/pkg/log/error"
+)
+
+// Los casos de uso autorizados envuelven a los reales y aplican LicensePolicy con
+// el principal del contexto. Sin principal (autenticación deshabilitada) delegan
+// sin restricciones.
+
+type authorizedLicenseIssuer struct {
+	next   contrats.LicenseIssuer
+	policy *LicensePolicy
+}
+
+func NewAuthorizedLicenseIssuer(next contrats.LicenseIssuer, policy *LicensePolicy) contrats.LicenseIssuer {
+	return &authorizedLicenseIssuer{next: next, policy: policy}
+}
+
+func (a *authorizedLicenseIssuer) Execute(ctx context.Context, createLicenseDTO dto.CreateLicenseDTO) (*dto.LicenseDTO, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return a.next.Execute(ctx, createLicenseDTO)
+	}
+
+	if err := a.policy.CanIssue(principal); err != nil {
+		return nil, err
+	}
+	return a.next.Execute(ctx, createLicenseDTO)
+}
+
+type authorizedLicenseRetriever struct {
+	next   contrats.LicenseRetriever
+	policy *LicensePolicy
+}
+
+func NewAuthorizedLicenseRetriever(next contrats.LicenseRetriever, policy *LicensePolicy) contrats.LicenseRetriever {
+	return &authorizedLicenseRetriever{next: next, policy: policy}
+}
+
+func (a *authorizedLicenseRetriever) Execute(ctx context.Context, folio string) (*dto.LicenseDTO, error) {
+	license, err := a.next.Execute(ctx, folio)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return license, nil
+	}
+
+	if !a.policy.CanView(principal, license) {
+		return nil, licenseNotVisible()
+	}
+	return a.policy.Redact(principal, license), nil
+}
+
+// licenseNotVisible responde igual que un folio inexistente: un 403 revelaría
+// qué folios existen a quien no puede verlos.
+func licenseNotVisible() error {
+	return errorInfo.NewAppError(errorInfo.ErrNotFound, "LicensePolicy", "CanView", "license not found")
+}
+
+type authorizedLicenseVersionRetriever struct {
+	next   contrats.LicenseVersionRetriever
+	policy *LicensePolicy
+}
+
+func NewAuthorizedLicenseVersionRetriever(next contrats.LicenseVersionRetriever, policy *LicensePolicy) contrats.LicenseVersionRetriever {
+	return &authorizedLicenseVersionRetriever{next: next, policy: policy}
+}
+
+func (a *authorizedLicenseVersionRetriever) Execute(ctx context.Context, folio string, version int) (*dto.LicenseDTO, error) {
+	license, err := a.next.Execute(ctx, folio, version)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return license, nil
+	}
+
+	if !a.policy.CanView(principal, license) {
+		return nil, licenseNotVisible()
+	}
+	return a.policy.Redact(principal, license), nil
+}
+
+type authorizedLicenseVersionsRetriever struct {
+	next   contrats.LicenseVersionsRetriever
+	policy *LicensePolicy
+}
+
+func NewAuthorizedLicenseVersionsRetriever(next contrats.LicenseVersionsRetriever, policy *LicensePolicy) contrats.LicenseVersionsRetriever {
+	return &authorizedLicenseVersionsRetriever{next: next, policy: policy}
+}
+
+// Execute decide el acceso con la versión vigente: paciente y médico no cambian
+// entre versiones, pero sí pueden cambiar los empleadores.
+func (a *authorizedLicenseVersionsRetriever) Execute(ctx context.Context, folio string) ([]*dto.LicenseVersionDTO, error) {
+	versions, err := a.next.Execute(ctx, folio)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return versions, nil
+	}
+
+	if len(versions) == 0 || !a.policy.CanView(principal, versions[len(versions)-1].License) {
+		return nil, licenseNotVisible()
+	}
+
+	redacted := make([]*dto.LicenseVersionDTO, 0, len(versions))
+	for _, version := range versions {
+		redacted = append(redacted, a.policy.RedactVersion(principal, version))
+	}
+	return redacted, nil
+}
+
+type authorizedLicensesByPatientRetriever struct {
+	next   contrats.LicensesByPatientRetriever
+	policy *LicensePolicy
+}
+
+func NewAuthorizedLicensesByPatientRetriever(next contrats.LicensesByPatientRetriever, policy *LicensePolicy) contrats.LicensesByPatientRetriever {
+	return &authorizedLicensesByPatientRetriever{next: next, policy: policy}
+}
+
+func (a *authorizedLicensesByPatientRetriever) Execute(ctx context.Context, filter dto.LicenseFilterDTO) ([]*dto.LicenseDTO, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return a.next.Execute(ctx, filter)
+	}
+
+	if err := a.policy.CanListByPatient(principal, filter.PatientID); err != nil {
+		return nil, err
+	}
+
+	licenses, err := a.next.Execute(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*dto.LicenseDTO, 0, len(licenses))
+	for _, license := range licenses {
+		if a.policy.CanView(principal, license) {
+			visible = append(visible, a.policy.Redact(principal, license))
+		}
+	}
+	return visible, nil
+}
+
+type authorizedLicenseVerifier struct {
+	next   contrats.LicenseVerifier
+	policy *LicensePolicy
+}
+
+func NewAuthorizedLicenseVerifier(next contrats.LicenseVerifier, policy *LicensePolicy) contrats.LicenseVerifier {
+	return &authorizedLicenseVerifier{next: next, policy: policy}
+}
+
+func (a *authorizedLicenseVerifier) Execute(ctx context.Context, folio string) (*dto.LicenseVerificationDTO, error) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if err := a.policy.CanVerify(principal); err != nil {
+			return nil, err
+		}
+	}
+	return a.next.Execute(ctx, folio)
+}
+
+type authorizedLicenseBatchVerifier struct {
+	next   contrats.LicenseBatchVerifier
+	policy *LicensePolicy
+}
+
+func NewAuthorizedLicenseBatchVerifier(next contrats.LicenseBatchVerifier, policy *LicensePolicy) contrats.LicenseBatchVerifier {
+	return &authorizedLicenseBatchVerifier{next: next, policy: policy}
+}
+
+func (a *authorizedLicenseBatchVerifier) Execute(ctx context.Context, request dto.BatchVerificationRequestDTO) (*dto.BatchVerificationDTO, error) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if err := a.policy.CanVerify(principal); err != nil {
+			return nil, err
+		}
+		request.RequirePatientID = a.policy.MustNamePatient(principal)
+	}
+	return a.next.Execute(ctx, request)
+}
+
+type authorizedLicenseImporter struct {
+	next   contrats.LicenseImporter
+	policy *LicensePolicy
+}
+
+func NewAuthorizedLicenseImporter(next contrats.LicenseImporter, policy *LicensePolicy) contrats.LicenseImporter {
+	return &authorizedLicenseImporter{next: next, policy: policy}
+}
+
+func (a *authorizedLicenseImporter) Execute(ctx context.Context, input io.Reader, options dto.ImportOptionsDTO) (*dto.ImportReportDTO, error) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if err := a.policy.CanImport(principal); err != nil {
+			return nil, err
+		}
+	}
+	return a.next.Execute(ctx, input, options)
+}
+
+type authorizedLicenseAmender struct {
+	next   contrats.LicenseAmender
+	policy *LicensePolicy
+}
+
+func NewAuthorizedLicenseAmender(next contrats.LicenseAmender, policy *LicensePolicy) contrats.LicenseAmender {
+	return &authorizedLicenseAmender{next: next, policy: policy}
+}
+
+func (a *authorizedLicenseAmender) Execute(ctx context.Context, folio string, amendment dto.AmendLicenseDTO, expectedRevision int) (*dto.LicenseDTO, error) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if err := a.policy.CanAmend(principal); err != nil {
+			return nil, err
+		}
+	}
+	return a.next.Execute(ctx, folio, amendment, expectedRevision)
+}
+
+type authorizedLicenseResolver struct {
+	next   contrats.LicenseResolver
+	policy *LicensePolicy
+}
+
+func NewAuthorizedLicenseResolver(next contrats.LicenseResolver, policy *LicensePolicy) contrats.LicenseResolver {
+	return &authorizedLicenseResolver{next: next, policy: policy}
+}
+
+func (a *authorizedLicenseResolver) Execute(ctx context.Context, folio string, resolution dto.ResolutionDTO, expectedRevision int) (*dto.LicenseDTO, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return a.next.Execute(ctx, folio, resolution, expectedRevision)
+	}
+
+	if err := a.policy.CanResolve(principal); err != nil {
+		return nil, err
+	}
+
+	license, err := a.next.Execute(ctx, folio, resolution, expectedRevision)
+	if err != nil {
+		return nil, err
+	}
+	return a.policy.Redact(principal, license), nil
+}
+
+// Las rutas de empleador responden siempre la vista redactada, incluso sin
+// principal, porque son la interfaz de RR.HH.
+
+type authorizedEmployerLicensesRetriever struct {
+	next   contrats.EmployerLicensesRetriever
+	policy *LicensePolicy
+}
+
+func NewAuthorizedEmployerLicensesRetriever(next contrats.EmployerLicensesRetriever, policy *LicensePolicy) contrats.EmployerLicensesRetriever {
+	return &authorizedEmployerLicensesRetriever{next: next, policy: policy}
+}
+
+func (a *authorizedEmployerLicensesRetriever) Execute(ctx context.Context, filter dto.EmployerLicenseFilterDTO) ([]*dto.LicenseDTO, error) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if err := a.policy.CanListEmployerLicenses(principal, filter.EmployerRut); err != nil {
+			return nil, err
+		}
+	}
+
+	licenses, err := a.next.Execute(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := make([]*dto.LicenseDTO, 0, len(licenses))
+	for _, license := range licenses {
+		redacted = append(redacted, a.policy.RedactForEmployer(license, filter.EmployerRut))
+	}
+	return redacted, nil
+}
+
+type authorizedEmployerAcknowledger struct {
+	next   contrats.EmployerAcknowledger
+	policy *LicensePolicy
+}
+
+func NewAuthorizedEmployerAcknowledger(next contrats.EmployerAcknowledger, policy *LicensePolicy) contrats.EmployerAcknowledger {
+	return &authorizedEmployerAcknowledger{next: next, policy: policy}
+}
+
+func (a *authorizedEmployerAcknowledger) Execute(ctx context.Context, folio string, employerRut string, expectedRevision int) (*dto.LicenseDTO, error) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if err := a.policy.CanAcknowledge(principal, employerRut); err != nil {
+			return nil, err
+		}
+	}
+
+	license, err := a.next.Execute(ctx, folio, employerRut, expectedRevision)
+	if err != nil {
+		return nil, err
+	}
+	return a.policy.RedactForEmployer(license, employerRut), nil
+}
+
+type authorizedLicenseExporter struct {
+	next   contrats.LicenseExporter
+	policy *LicensePolicy
+}
+
+func NewAuthorizedLicenseExporter(next contrats.LicenseExporter, policy *LicensePolicy) contrats.LicenseExporter {
+	return &authorizedLicenseExporter{next: next, policy: policy}
+}
+
+func (a *authorizedLicenseExporter) Execute(ctx context.Context, request dto.ExportRequestDTO, output io.Writer) (int, error) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if err := a.policy.CanExport(principal); err != nil {
+			return 0, err
+		}
+	}
+	return a.next.Execute(ctx, request, output)
+}
+
+type authorizedExportJobStarter struct {
+	next   contrats.ExportJobStarter
+	policy *LicensePolicy
+}
+
+func NewAuthorizedExportJobStarter(next contrats.ExportJobStarter, policy *LicensePolicy) contrats.ExportJobStarter {
+	return &authorizedExportJobStarter{next: next, policy: policy}
+}
+
+func (a *authorizedExportJobStarter) Execute(ctx context.Context, request dto.ExportRequestDTO) (*dto.ExportJobDTO, error) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if err := a.policy.CanExport(principal); err != nil {
+			return nil, err
+		}
+	}
+	return a.next.Execute(ctx, request)
+}
+
+// Consultar y descargar un trabajo también exige CanExport: quien perdió el rol
+// no puede retirar lo que exportó antes. Que el trabajo sea suyo lo comprueba
+// el caso de uso.
+type authorizedExportJobRetriever struct {
+	next   contrats.ExportJobRetriever
+	policy *LicensePolicy
+}
+
+func NewAuthorizedExportJobRetriever(next contrats.ExportJobRetriever, policy *LicensePolicy) contrats.ExportJobRetriever {
+	return &authorizedExportJobRetriever{next: next, policy: policy}
+}
+
+func (a *authorizedExportJobRetriever) Execute(ctx context.Context, id string) (*dto.ExportJobDTO, error) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if err := a.policy.CanExport(principal); err != nil {
+			return nil, err
+		}
+	}
+	return a.next.Execute(ctx, id)
+}
+
+type authorizedExportJobDownloader struct {
+	next   contrats.ExportJobDownloader
+	policy *LicensePolicy
+}
+
+func NewAuthorizedExportJobDownloader(next contrats.ExportJobDownloader, policy *LicensePolicy) contrats.ExportJobDownloader {
+	return &authorizedExportJobDownloader{next: next, policy: policy}
+}
+
+func (a *authorizedExportJobDownloader) Execute(ctx context.Context, id string) (*dto.ExportFileDTO, error) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if err := a.policy.CanExport(principal); err != nil {
+			return nil, err
+		}
+	}
+	return a.next.Execute(ctx, id)
+}
@@ -0,0 +1,221 @@
+package policy
+
+import (
+	"license-service/internal/application/dto"
+	"license-service/pkg/auth"
+	errorInfo "license-service/pkg/log/error"
+)
+
+// LicensePolicy concentra las reglas de acceso por rol sobre licencias:
+//   - doctor: solo las licencias que emitió
+//   - patient: solo sus propias licencias
+//   - employer: solo licencias que se le entregan, sin diagnóstico ni los demás
+//     empleadores del trabajador
+//   - insurer y admin: todas las licencias con todos sus campos
+type LicensePolicy struct{}
+
+func NewLicensePolicy() *LicensePolicy {
+	return &LicensePolicy{}
+}
+
+func (p *LicensePolicy) CanIssue(principal *auth.Principal) error {
+	if principal.HasRole(auth.RoleDoctor) {
+		return nil
+	}
+	return forbidden("CanIssue", "only doctors can issue licenses")
+}
+
+// CanImport reserva la importación masiva a administradores: las filas traen
+// el médico emisor de cada licencia en vez de tomarlo del token.
+func (p *LicensePolicy) CanImport(principal *auth.Principal) error {
+	if principal.HasRole(auth.RoleAdmin) {
+		return nil
+	}
+	return forbidden("CanImport", "only administrators can import licenses")
+}
+
+// CanExport reserva la exportación masiva a aseguradoras y administradores,
+// los únicos roles que ven todas las licencias con todos sus campos.
+func (p *LicensePolicy) CanExport(principal *auth.Principal) error {
+	if principal.HasRole(auth.RoleInsurer) || principal.HasRole(auth.RoleAdmin) {
+		return nil
+	}
+	return forbidden("CanExport", "only insurers and administrators can export licenses")
+}
+
+// CanAmend limita las enmiendas a médicos y administradores. Que el médico sea
+// el emisor lo comprueba el caso de uso.
+func (p *LicensePolicy) CanAmend(principal *auth.Principal) error {
+	if principal.HasRole(auth.RoleDoctor) || principal.HasRole(auth.RoleAdmin) {
+		return nil
+	}
+	return forbidden("CanAmend", "only doctors can amend licenses")
+}
+
+// CanResolve limita la resolución a aseguradoras y administradores. Que la
+// aseguradora sea la asignada a la licencia lo comprueba el caso de uso.
+func (p *LicensePolicy) CanResolve(principal *auth.Principal) error {
+	if principal.HasRole(auth.RoleInsurer) || principal.HasRole(auth.RoleAdmin) {
+		return nil
+	}
+	return forbidden("CanResolve", "only insurers can adjudicate licenses")
+}
+
+func (p *LicensePolicy) CanVerify(principal *auth.Principal) error {
+	if len(principal.Roles) == 0 {
+		return forbidden("CanVerify", "principal has no roles")
+	}
+	return nil
+}
+
+// MustNamePatient indica si una verificación por lotes debe traer el paciente
+// de cada folio. Solo aseguradoras y administradores, que ya pueden ver
+// cualquier licencia, verifican folios sueltos.
+func (p *LicensePolicy) MustNamePatient(principal *auth.Principal) bool {
+	return !principal.HasRole(auth.RoleInsurer) && !principal.HasRole(auth.RoleAdmin)
+}
+
+func (p *LicensePolicy) CanListByPatient(principal *auth.Principal, patientID string) error {
+	switch {
+	case principal.HasRole(auth.RoleAdmin), principal.HasRole(auth.RoleInsurer), principal.HasRole(auth.RoleDoctor):
+		return nil
+	case principal.HasRole(auth.RolePatient) && principal.Rut == patientID:
+		return nil
+	case principal.HasRole(auth.RoleEmployer):
+		// El listado se filtra luego con CanView licencia por licencia.
+		return nil
+	}
+	return forbidden("CanListByPatient", "not allowed to list licenses of this patient")
+}
+
+func (p *LicensePolicy) CanView(principal *auth.Principal, license *dto.LicenseDTO) bool {
+	switch {
+	case principal.HasRole(auth.RoleAdmin), principal.HasRole(auth.RoleInsurer):
+		return true
+	case principal.HasRole(auth.RoleDoctor) && principal.DoctorID != "" && principal.DoctorID == license.DoctorID:
+		return true
+	case principal.HasRole(auth.RolePatient) && principal.Rut == license.PatientID:
+		return true
+	case principal.HasRole(auth.RoleEmployer):
+		return p.isLicenseEmployer(principal, license)
+	}
+	return false
+}
+
+// isLicenseEmployer usa los empleadores de la licencia. Las licencias emitidas
+// antes de registrar empleadores no tienen ninguno y se resuelven con el claim
+// de trabajadores del token.
+func (p *LicensePolicy) isLicenseEmployer(principal *auth.Principal, license *dto.LicenseDTO) bool {
+	if len(license.Employers) == 0 {
+		return principal.HasWorker(license.PatientID)
+	}
+	for _, employer := range license.Employers {
+		if principal.Rut != "" && employer.Rut == principal.Rut {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *LicensePolicy) CanListEmployerLicenses(principal *auth.Principal, employerRut string) error {
+	switch {
+	case principal.HasRole(auth.RoleAdmin), principal.HasRole(auth.RoleInsurer):
+		return nil
+	case principal.HasRole(auth.RoleEmployer) && principal.Rut != "" && principal.Rut == employerRut:
+		return nil
+	}
+	return forbidden("CanListEmployerLicenses", "not allowed to list licenses of this employer")
+}
+
+// CanAcknowledge permite confirmar la recepción solo al propio empleador (o a un
+// administrador en su nombre).
+func (p *LicensePolicy) CanAcknowledge(principal *auth.Principal, employerRut string) error {
+	switch {
+	case principal.HasRole(auth.RoleAdmin):
+		return nil
+	case principal.HasRole(auth.RoleEmployer) && principal.Rut != "" && principal.Rut == employerRut:
+		return nil
+	}
+	return forbidden("CanAcknowledge", "not allowed to acknowledge licenses for this employer")
+}
+
+func (p *LicensePolicy) CanSeeDiagnosis(principal *auth.Principal, license *dto.LicenseDTO) bool {
+	switch {
+	case principal.HasRole(auth.RoleAdmin), principal.HasRole(auth.RoleInsurer):
+		return true
+	case principal.HasRole(auth.RoleDoctor) && principal.DoctorID != "" && principal.DoctorID == license.DoctorID:
+		return true
+	case principal.HasRole(auth.RolePatient) && principal.Rut == license.PatientID:
+		return true
+	}
+	return false
+}
+
+// Redact devuelve una copia de la licencia sin los campos a los que el rol no
+// tiene derecho; nunca modifica el DTO recibido.
+func (p *LicensePolicy) Redact(principal *auth.Principal, license *dto.LicenseDTO) *dto.LicenseDTO {
+	if p.CanSeeDiagnosis(principal, license) {
+		return license
+	}
+
+	redacted := redactClinicalData(license)
+	if principal.HasRole(auth.RoleEmployer) {
+		redacted.Employers = onlyEmployer(license.Employers, principal.Rut)
+	}
+	return redacted
+}
+
+// RedactVersion aplica Redact a la licencia de la versión y oculta los valores
+// anteriores y nuevos de los campos clínicos enmendados.
+func (p *LicensePolicy) RedactVersion(principal *auth.Principal, version *dto.LicenseVersionDTO) *dto.LicenseVersionDTO {
+	if p.CanSeeDiagnosis(principal, version.License) {
+		return version
+	}
+
+	redacted := *version
+	redacted.License = p.Redact(principal, version.License)
+	redacted.Changes = make([]dto.FieldChangeDTO, 0, len(version.Changes))
+	for _, change := range version.Changes {
+		if clinicalFields[change.Field] {
+			change.From, change.To = "", ""
+		}
+		redacted.Changes = append(redacted.Changes, change)
+	}
+	return &redacted
+}
+
+// clinicalFields son los campos enmendables que Redact oculta.
+var clinicalFields = map[string]bool{
+	"diagnosis":   true,
+	"restAddress": true,
+}
+
+// RedactForEmployer es la vista de la bandeja de un empleador: siempre sin datos
+// clínicos y solo con la recepción de ese empleador.
+func (p *LicensePolicy) RedactForEmployer(license *dto.LicenseDTO, employerRut string) *dto.LicenseDTO {
+	redacted := redactClinicalData(license)
+	redacted.Employers = onlyEmployer(license.Employers, employerRut)
+	return redacted
+}
+
+func redactClinicalData(license *dto.LicenseDTO) *dto.LicenseDTO {
+	redacted := *license
+	redacted.Diagnosis = ""
+	redacted.ChildRut = ""
+	redacted.RestAddress = ""
+	redacted.Adjudication.Reason = ""
+	return &redacted
+}
+
+func onlyEmployer(employers []dto.LicenseEmployerDTO, rut string) []dto.LicenseEmployerDTO {
+	for _, employer := range employers {
+		if employer.Rut == rut {
+			return []dto.LicenseEmployerDTO{employer}
+		}
+	}
+	return nil
+}
+
+func forbidden(operation, message string) error {
+	return errorInfo.NewAppError(errorInfo.ErrForbidden, "LicensePolicy", operation, message)
+}
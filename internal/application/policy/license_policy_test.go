@@ -0,0 +1,273 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+
+	"license-service/internal/application/dto"
+	"license-service/pkg/auth"
+)
+
+const (
+	issuingDoctor = "DOC-1"
+	patientRut    = "11111111-1"
+	employerRut   = "76543210-K"
+	otherEmployer = "77777777-7"
+)
+
+// sampleLicense arma siempre una licencia nueva, así cada prueba puede comparar
+// la entrada con una copia intacta.
+func sampleLicense() *dto.LicenseDTO {
+	return &dto.LicenseDTO{
+		Folio:       "L-1",
+		PatientID:   patientRut,
+		DoctorID:    issuingDoctor,
+		Diagnosis:   "J06.9",
+		ChildRut:    "22222222-2",
+		RestAddress: "Av. Siempre Viva 742",
+		Employers: []dto.LicenseEmployerDTO{
+			{Rut: employerRut},
+			{Rut: otherEmployer, AcknowledgedAt: "2025-01-02T10:00:00Z"},
+		},
+		Adjudication: dto.LicenseAdjudicationDTO{Status: "reduced", Reason: "reposo excesivo"},
+	}
+}
+
+func withoutEmployers() *dto.LicenseDTO {
+	license := sampleLicense()
+	license.Employers = nil
+	return license
+}
+
+var (
+	admin          = &auth.Principal{Roles: []string{auth.RoleAdmin}}
+	insurer        = &auth.Principal{Roles: []string{auth.RoleInsurer}}
+	doctor         = &auth.Principal{Roles: []string{auth.RoleDoctor}, DoctorID: issuingDoctor}
+	otherDoctor    = &auth.Principal{Roles: []string{auth.RoleDoctor}, DoctorID: "DOC-2"}
+	doctorNoID     = &auth.Principal{Roles: []string{auth.RoleDoctor}}
+	patient        = &auth.Principal{Roles: []string{auth.RolePatient}, Rut: patientRut}
+	otherPatient   = &auth.Principal{Roles: []string{auth.RolePatient}, Rut: "33333333-3"}
+	employer       = &auth.Principal{Roles: []string{auth.RoleEmployer}, Rut: employerRut}
+	strangerEmp    = &auth.Principal{Roles: []string{auth.RoleEmployer}, Rut: "88888888-8"}
+	workerEmployer = &auth.Principal{Roles: []string{auth.RoleEmployer}, Rut: "88888888-8", Workers: []string{patientRut}}
+	noRoles        = &auth.Principal{Subject: "anonymous"}
+)
+
+func TestCanView(t *testing.T) {
+	policy := NewLicensePolicy()
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		license   *dto.LicenseDTO
+		want      bool
+	}{
+		{"admin", admin, sampleLicense(), true},
+		{"insurer", insurer, sampleLicense(), true},
+		{"issuing doctor", doctor, sampleLicense(), true},
+		{"other doctor", otherDoctor, sampleLicense(), false},
+		{"doctor without id", doctorNoID, sampleLicense(), false},
+		{"own patient", patient, sampleLicense(), true},
+		{"other patient", otherPatient, sampleLicense(), false},
+		{"listed employer", employer, sampleLicense(), true},
+		{"unlisted employer", strangerEmp, sampleLicense(), false},
+		{"unlisted employer with worker claim", workerEmployer, sampleLicense(), false},
+		{"employer via worker claim without employers", workerEmployer, withoutEmployers(), true},
+		{"employer without worker claim nor employers", employer, withoutEmployers(), false},
+		{"no roles", noRoles, sampleLicense(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.CanView(tt.principal, tt.license); got != tt.want {
+				t.Errorf("CanView = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanSeeDiagnosis(t *testing.T) {
+	policy := NewLicensePolicy()
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		want      bool
+	}{
+		{"admin", admin, true},
+		{"insurer", insurer, true},
+		{"issuing doctor", doctor, true},
+		{"other doctor", otherDoctor, false},
+		{"own patient", patient, true},
+		{"other patient", otherPatient, false},
+		{"employer", employer, false},
+		{"no roles", noRoles, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.CanSeeDiagnosis(tt.principal, sampleLicense()); got != tt.want {
+				t.Errorf("CanSeeDiagnosis = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMustNamePatient(t *testing.T) {
+	policy := NewLicensePolicy()
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		want      bool
+	}{
+		{"admin", admin, false},
+		{"insurer", insurer, false},
+		{"doctor", doctor, true},
+		{"patient", patient, true},
+		{"employer", employer, true},
+		{"no roles", noRoles, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.MustNamePatient(tt.principal); got != tt.want {
+				t.Errorf("MustNamePatient = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	policy := NewLicensePolicy()
+	tests := []struct {
+		name          string
+		principal     *auth.Principal
+		wantClinical  bool
+		wantEmployers []dto.LicenseEmployerDTO
+	}{
+		{"admin", admin, true, sampleLicense().Employers},
+		{"insurer", insurer, true, sampleLicense().Employers},
+		{"issuing doctor", doctor, true, sampleLicense().Employers},
+		{"own patient", patient, true, sampleLicense().Employers},
+		{"employer sees only itself", employer, false, []dto.LicenseEmployerDTO{{Rut: employerRut}}},
+		{"unlisted employer sees no employers", strangerEmp, false, nil},
+		{"other doctor", otherDoctor, false, sampleLicense().Employers},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := sampleLicense()
+			got := policy.Redact(tt.principal, input)
+
+			if !reflect.DeepEqual(input, sampleLicense()) {
+				t.Fatal("Redact modified its input")
+			}
+			clinical := got.Diagnosis != "" && got.ChildRut != "" && got.RestAddress != "" && got.Adjudication.Reason != ""
+			hidden := got.Diagnosis == "" && got.ChildRut == "" && got.RestAddress == "" && got.Adjudication.Reason == ""
+			if tt.wantClinical && !clinical {
+				t.Errorf("clinical data hidden: %+v", got)
+			}
+			if !tt.wantClinical && !hidden {
+				t.Errorf("clinical data leaked: %+v", got)
+			}
+			if !reflect.DeepEqual(got.Employers, tt.wantEmployers) {
+				t.Errorf("Employers = %+v, want %+v", got.Employers, tt.wantEmployers)
+			}
+		})
+	}
+}
+
+func TestRedactVersion(t *testing.T) {
+	policy := NewLicensePolicy()
+	sampleVersion := func() *dto.LicenseVersionDTO {
+		return &dto.LicenseVersionDTO{
+			Version: 2,
+			Changes: []dto.FieldChangeDTO{
+				{Field: "diagnosis", From: "J06.9", To: "J11.1"},
+				{Field: "restAddress", From: "Calle 1", To: "Calle 2"},
+				{Field: "restType", From: "total", To: "partial"},
+			},
+			License: sampleLicense(),
+		}
+	}
+
+	t.Run("employer", func(t *testing.T) {
+		input := sampleVersion()
+		got := policy.RedactVersion(employer, input)
+
+		if !reflect.DeepEqual(input, sampleVersion()) {
+			t.Fatal("RedactVersion modified its input")
+		}
+		want := []dto.FieldChangeDTO{
+			{Field: "diagnosis"},
+			{Field: "restAddress"},
+			{Field: "restType", From: "total", To: "partial"},
+		}
+		if !reflect.DeepEqual(got.Changes, want) {
+			t.Errorf("Changes = %+v, want %+v", got.Changes, want)
+		}
+		if got.License.Diagnosis != "" {
+			t.Errorf("diagnosis leaked: %q", got.License.Diagnosis)
+		}
+	})
+
+	t.Run("issuing doctor", func(t *testing.T) {
+		got := policy.RedactVersion(doctor, sampleVersion())
+		if !reflect.DeepEqual(got, sampleVersion()) {
+			t.Errorf("version redacted for the issuing doctor: %+v", got)
+		}
+	})
+}
+
+func TestRoleMatrix(t *testing.T) {
+	policy := NewLicensePolicy()
+	principals := map[string]*auth.Principal{
+		"admin": admin, "insurer": insurer, "doctor": doctor, "patient": patient, "employer": employer, "none": noRoles,
+	}
+	checks := map[string]func(*auth.Principal) error{
+		"CanIssue":   policy.CanIssue,
+		"CanImport":  policy.CanImport,
+		"CanExport":  policy.CanExport,
+		"CanAmend":   policy.CanAmend,
+		"CanResolve": policy.CanResolve,
+		"CanVerify":  policy.CanVerify,
+	}
+	allowed := map[string][]string{
+		"CanIssue":   {"doctor"},
+		"CanImport":  {"admin"},
+		"CanExport":  {"admin", "insurer"},
+		"CanAmend":   {"admin", "doctor"},
+		"CanResolve": {"admin", "insurer"},
+		"CanVerify":  {"admin", "insurer", "doctor", "patient", "employer"},
+	}
+
+	for check, fn := range checks {
+		for name, principal := range principals {
+			want := false
+			for _, role := range allowed[check] {
+				want = want || role == name
+			}
+			if got := fn(principal) == nil; got != want {
+				t.Errorf("%s(%s) allowed = %v, want %v", check, name, got, want)
+			}
+		}
+	}
+}
+
+func TestCanListByPatient(t *testing.T) {
+	policy := NewLicensePolicy()
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		want      bool
+	}{
+		{"admin", admin, true},
+		{"insurer", insurer, true},
+		{"doctor", doctor, true},
+		{"own patient", patient, true},
+		{"other patient", otherPatient, false},
+		{"employer", employer, true},
+		{"no roles", noRoles, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.CanListByPatient(tt.principal, patientRut) == nil; got != tt.want {
+				t.Errorf("CanListByPatient allowed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package contrats
+
+import (
+	"context"
+	dto "license-service/internal/application/dto"
+	"license-service/pkg/auth"
+)
+
+// Para POST /admin/api-keys
+type APIKeyCreator interface {
+	Execute(ctx context.Context, createAPIKeyDTO dto.CreateAPIKeyDTO) (*dto.IssuedAPIKeyDTO, error)
+}
+
+// Para GET /admin/api-keys
+type APIKeyLister interface {
+	Execute(ctx context.Context) ([]*dto.APIKeyDTO, error)
+}
+
+// Para POST /admin/api-keys/{prefix}/rotate
+type APIKeyRotator interface {
+	Execute(ctx context.Context, prefix string) (*dto.IssuedAPIKeyDTO, error)
+}
+
+// Para DELETE /admin/api-keys/{prefix}
+type APIKeyRevoker interface {
+	Execute(ctx context.Context, prefix string) (*dto.APIKeyDTO, error)
+}
+
+// Para el header X-API-Key en cualquier ruta protegida
+type APIKeyAuthenticator interface {
+	Execute(ctx context.Context, plaintext string, scope string) (*auth.Principal, error)
+}
@@ -0,0 +1,33 @@
+package contrats
+
+import (
+	"context"
+	"io"
+	dto "license-service/internal/application/dto"
+	"time"
+)
+
+// Para GET /licenses/export; devuelve la cantidad de licencias escritas en output.
+type LicenseExporter interface {
+	Execute(ctx context.Context, request dto.ExportRequestDTO, output io.Writer) (int, error)
+}
+
+// Para GET /licenses/export?async=true
+type ExportJobStarter interface {
+	Execute(ctx context.Context, request dto.ExportRequestDTO) (*dto.ExportJobDTO, error)
+}
+
+// Para GET /licenses/export/jobs/{id}
+type ExportJobRetriever interface {
+	Execute(ctx context.Context, id string) (*dto.ExportJobDTO, error)
+}
+
+// Para GET /licenses/export/jobs/{id}/download
+type ExportJobDownloader interface {
+	Execute(ctx context.Context, id string) (*dto.ExportFileDTO, error)
+}
+
+// Para el barrido periódico de exportaciones vencidas
+type ExportJobPurger interface {
+	Execute(ctx context.Context, now time.Time) (int, error)
+}
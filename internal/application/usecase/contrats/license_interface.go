@@ -2,7 +2,9 @@ package contrats
 
 import (
 	"context"
+	"io"
 	dto "license-service/internal/application/dto"
+	"time"
 )
 
 // Para POST /licenses
@@ -15,12 +17,65 @@ type LicenseRetriever interface {
 	Execute(ctx context.Context, folio string) (*dto.LicenseDTO, error)
 }
 
-// Para GET /licenses?patientId={id}
+// Para GET /licenses?patientId={id}&type={type}
 type LicensesByPatientRetriever interface {
-	Execute(ctx context.Context, patientID string) ([]*dto.LicenseDTO, error)
+	Execute(ctx context.Context, filter dto.LicenseFilterDTO) ([]*dto.LicenseDTO, error)
 }
 
 // Para GET /licenses/{folio}/verify
 type LicenseVerifier interface {
-	Execute(ctx context.Context, folio string) (bool, error)
+	Execute(ctx context.Context, folio string) (*dto.LicenseVerificationDTO, error)
+}
+
+// Para POST /licenses/verify:batch
+type LicenseBatchVerifier interface {
+	Execute(ctx context.Context, request dto.BatchVerificationRequestDTO) (*dto.BatchVerificationDTO, error)
+}
+
+// Para POST /licenses/import y licensectl import. Si la lectura del archivo se
+// corta, devuelve el informe parcial junto con el error.
+type LicenseImporter interface {
+	Execute(ctx context.Context, input io.Reader, options dto.ImportOptionsDTO) (*dto.ImportReportDTO, error)
+}
+
+// Para GET /licenses/{folio}?version={n}
+type LicenseVersionRetriever interface {
+	Execute(ctx context.Context, folio string, version int) (*dto.LicenseDTO, error)
+}
+
+// Para GET /licenses/{folio}/versions
+type LicenseVersionsRetriever interface {
+	Execute(ctx context.Context, folio string) ([]*dto.LicenseVersionDTO, error)
+}
+
+// Las escrituras reciben la revisión del If-Match; 0 no exige ninguna.
+
+// Para POST /licenses/{folio}/amendments
+type LicenseAmender interface {
+	Execute(ctx context.Context, folio string, amendment dto.AmendLicenseDTO, expectedRevision int) (*dto.LicenseDTO, error)
+}
+
+// Para POST /licenses/{folio}/resolution
+type LicenseResolver interface {
+	Execute(ctx context.Context, folio string, resolution dto.ResolutionDTO, expectedRevision int) (*dto.LicenseDTO, error)
+}
+
+// Para GET /employers/{rut}/licenses?status={status}&acknowledged={bool}
+type EmployerLicensesRetriever interface {
+	Execute(ctx context.Context, filter dto.EmployerLicenseFilterDTO) ([]*dto.LicenseDTO, error)
+}
+
+// Para POST /employers/{rut}/licenses/{folio}/acknowledgment
+type EmployerAcknowledger interface {
+	Execute(ctx context.Context, folio string, employerRut string, expectedRevision int) (*dto.LicenseDTO, error)
+}
+
+// Para licensectl revoke --folio
+type LicenseRevoker interface {
+	Execute(ctx context.Context, folio string) (*dto.LicenseDTO, error)
+}
+
+// Para licensectl expire
+type LicenseExpirer interface {
+	Execute(ctx context.Context, asOf time.Time) (*dto.ExpirationSweepDTO, error)
 }
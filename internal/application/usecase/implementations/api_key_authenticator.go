@@ -0,0 +1,83 @@
+package implementations
+
+import (
+	"context"
+	"license-service/internal/application/usecase/contrats"
+	"license-service/internal/domain/repositories"
+	"license-service/pkg/auth"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"time"
+)
+
+type APIKeyAuthenticatorUseCase struct {
+	apiKeyRepository repositories.APIKeyRepository
+}
+
+func NewAPIKeyAuthenticatorUseCase(apiKeyRepository repositories.APIKeyRepository) contrats.APIKeyAuthenticator {
+	return &APIKeyAuthenticatorUseCase{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+func (usecase *APIKeyAuthenticatorUseCase) Execute(ctx context.Context, plaintext string, scope string) (_ *auth.Principal, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "APIKeyAuthenticatorUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("APIKeyAuthenticatorUseCase", err)
+	}()
+
+	prefix, ok := auth.ParseAPIKeyPrefix(plaintext)
+	if !ok {
+		return nil, unauthorizedAPIKey("malformed api key")
+	}
+
+	key, err := usecase.apiKeyRepository.FindByPrefix(ctx, prefix)
+	if err != nil {
+		logger.FromContext(ctx).Error("APIKeyAuthenticatorUseCase", "Execute", err, "failed to retrieve api key from repository")
+		return nil, err
+	}
+
+	if key == nil || !auth.APIKeyMatches(plaintext, key.Hash) {
+		return nil, unauthorizedAPIKey("unknown api key")
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		logger.FromContext(ctx).Info("APIKeyAuthenticatorUseCase", "Execute", "rejected inactive api key with prefix: "+prefix)
+		return nil, unauthorizedAPIKey("api key is revoked or expired")
+	}
+
+	if !key.AllowsScope(scope) {
+		logger.FromContext(ctx).Info("APIKeyAuthenticatorUseCase", "Execute", "api key "+prefix+" is not scoped for "+scope)
+		return nil, errorInfo.NewAppError(
+			errorInfo.ErrForbidden,
+			"APIKeyAuthenticatorUseCase",
+			"Execute",
+			"api key is not allowed on this route",
+		)
+	}
+
+	if err := usecase.apiKeyRepository.RecordUsage(ctx, prefix, now); err != nil {
+		logger.FromContext(ctx).Error("APIKeyAuthenticatorUseCase", "Execute", err, "failed to record api key usage")
+	}
+
+	return &auth.Principal{
+		Subject: "apikey:" + key.Prefix,
+		Roles:   []string{key.Role},
+		Rut:     key.OwnerRut,
+		Method:  auth.MethodAPIKey,
+		APIKey:  key.Prefix,
+	}, nil
+}
+
+func unauthorizedAPIKey(message string) error {
+	return errorInfo.NewAppError(
+		errorInfo.ErrUnauthorized,
+		"APIKeyAuthenticatorUseCase",
+		"Execute",
+		message,
+	)
+}
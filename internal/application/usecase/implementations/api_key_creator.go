@@ -0,0 +1,155 @@
+package implementations
+
+import (
+	"context"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	model "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	"license-service/pkg/auth"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"strings"
+	"time"
+)
+
+// APIKeyCreatorUseCase emite API keys. knownScopes son los nombres de ruta que
+// puede llevar una key: un scope mal escrito daría una key que no sirve para
+// nada.
+type APIKeyCreatorUseCase struct {
+	apiKeyRepository repositories.APIKeyRepository
+	knownScopes      map[string]bool
+}
+
+func NewAPIKeyCreatorUseCase(apiKeyRepository repositories.APIKeyRepository, knownScopes []string) contrats.APIKeyCreator {
+	known := make(map[string]bool, len(knownScopes)+1)
+	known[model.APIKeyScopeAll] = true
+	for _, scope := range knownScopes {
+		known[scope] = true
+	}
+	return &APIKeyCreatorUseCase{
+		apiKeyRepository: apiKeyRepository,
+		knownScopes:      known,
+	}
+}
+
+func (usecase *APIKeyCreatorUseCase) Execute(ctx context.Context, createAPIKeyDTO dto.CreateAPIKeyDTO) (_ *dto.IssuedAPIKeyDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "APIKeyCreatorUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("APIKeyCreatorUseCase", err)
+	}()
+
+	scopes := normalizeScopes(createAPIKeyDTO.Scopes)
+
+	if err := validateAPIKeyRequest(createAPIKeyDTO, scopes, usecase.knownScopes); err != nil {
+		logger.FromContext(ctx).Error("APIKeyCreatorUseCase", "Execute", err, "validation failed")
+		return nil, err
+	}
+
+	key := &model.APIKey{
+		Name:      strings.TrimSpace(createAPIKeyDTO.Name),
+		Role:      createAPIKeyDTO.Role,
+		OwnerRut:  createAPIKeyDTO.OwnerRut,
+		Scopes:    scopes,
+		ExpiresAt: createAPIKeyDTO.ExpiresAt,
+		CreatedAt: time.Now(),
+	}
+
+	plaintext, err := issueAPIKey(ctx, usecase.apiKeyRepository, key)
+	if err != nil {
+		logger.FromContext(ctx).Error("APIKeyCreatorUseCase", "Execute", err, "failed to save api key")
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("APIKeyCreatorUseCase", "Execute", "api key created with prefix: "+key.Prefix)
+	return &dto.IssuedAPIKeyDTO{APIKeyDTO: *mapper.ToAPIKeyDTO(key), Key: plaintext}, nil
+}
+
+// issueAPIKey genera el secreto, completa prefijo y hash en key y la persiste.
+func issueAPIKey(ctx context.Context, apiKeyRepository repositories.APIKeyRepository, key *model.APIKey) (string, error) {
+	plaintext, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return "", errorInfo.WrapError(
+			errorInfo.ErrInternalError,
+			"APIKeyCreatorUseCase",
+			"issueAPIKey",
+			"failed to generate api key",
+			err,
+		)
+	}
+
+	key.Prefix = prefix
+	key.Hash = hash
+
+	if err := apiKeyRepository.Save(ctx, key); err != nil {
+		return "", err
+	}
+	return plaintext, nil
+}
+
+func validateAPIKeyRequest(createAPIKeyDTO dto.CreateAPIKeyDTO, scopes []string, knownScopes map[string]bool) error {
+	if strings.TrimSpace(createAPIKeyDTO.Name) == "" {
+		return errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			"APIKeyCreatorUseCase",
+			"validateAPIKeyRequest",
+			"name is required",
+		)
+	}
+
+	switch createAPIKeyDTO.Role {
+	case auth.RoleEmployer, auth.RoleInsurer, auth.RoleAdmin:
+	default:
+		return errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			"APIKeyCreatorUseCase",
+			"validateAPIKeyRequest",
+			"role must be employer, insurer or admin",
+		)
+	}
+
+	if len(scopes) == 0 {
+		return errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			"APIKeyCreatorUseCase",
+			"validateAPIKeyRequest",
+			"at least one scope is required",
+		)
+	}
+
+	for _, scope := range scopes {
+		if !knownScopes[scope] {
+			return errorInfo.NewAppError(
+				errorInfo.ErrInvalidData,
+				"APIKeyCreatorUseCase",
+				"validateAPIKeyRequest",
+				"unknown scope: "+scope,
+			)
+		}
+	}
+
+	if createAPIKeyDTO.ExpiresAt != nil && !createAPIKeyDTO.ExpiresAt.After(time.Now()) {
+		return errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			"APIKeyCreatorUseCase",
+			"validateAPIKeyRequest",
+			"expiresAt must be in the future",
+		)
+	}
+
+	return nil
+}
+
+func normalizeScopes(scopes []string) []string {
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,38 @@
+package implementations
+
+import (
+	"context"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	"license-service/internal/domain/repositories"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+)
+
+type APIKeyListerUseCase struct {
+	apiKeyRepository repositories.APIKeyRepository
+}
+
+func NewAPIKeyListerUseCase(apiKeyRepository repositories.APIKeyRepository) contrats.APIKeyLister {
+	return &APIKeyListerUseCase{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+func (usecase *APIKeyListerUseCase) Execute(ctx context.Context) (_ []*dto.APIKeyDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "APIKeyListerUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("APIKeyListerUseCase", err)
+	}()
+
+	keys, err := usecase.apiKeyRepository.FindAll(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("APIKeyListerUseCase", "Execute", err, "failed to retrieve api keys from repository")
+		return nil, err
+	}
+
+	return mapper.ToAPIKeyDTOs(keys), nil
+}
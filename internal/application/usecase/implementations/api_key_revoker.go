@@ -0,0 +1,46 @@
+package implementations
+
+import (
+	"context"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	"license-service/internal/domain/repositories"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"time"
+)
+
+type APIKeyRevokerUseCase struct {
+	apiKeyRepository repositories.APIKeyRepository
+}
+
+func NewAPIKeyRevokerUseCase(apiKeyRepository repositories.APIKeyRepository) contrats.APIKeyRevoker {
+	return &APIKeyRevokerUseCase{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+func (usecase *APIKeyRevokerUseCase) Execute(ctx context.Context, prefix string) (_ *dto.APIKeyDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "APIKeyRevokerUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("APIKeyRevokerUseCase", err)
+	}()
+
+	key, err := findActiveAPIKey(ctx, usecase.apiKeyRepository, "APIKeyRevokerUseCase", prefix)
+	if err != nil {
+		logger.FromContext(ctx).Error("APIKeyRevokerUseCase", "Execute", err, "api key cannot be revoked")
+		return nil, err
+	}
+
+	key.Revoke(time.Now())
+	if err := usecase.apiKeyRepository.Update(ctx, key); err != nil {
+		logger.FromContext(ctx).Error("APIKeyRevokerUseCase", "Execute", err, "failed to revoke api key")
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("APIKeyRevokerUseCase", "Execute", "api key revoked with prefix: "+prefix)
+	return mapper.ToAPIKeyDTO(key), nil
+}
@@ -0,0 +1,101 @@
+package implementations
+
+import (
+	"context"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	model "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"time"
+)
+
+type APIKeyRotatorUseCase struct {
+	apiKeyRepository repositories.APIKeyRepository
+}
+
+func NewAPIKeyRotatorUseCase(apiKeyRepository repositories.APIKeyRepository) contrats.APIKeyRotator {
+	return &APIKeyRotatorUseCase{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+// Execute emite una clave nueva con el mismo nombre, rol, dueño, scopes y
+// expiración que la actual y revoca la anterior.
+func (usecase *APIKeyRotatorUseCase) Execute(ctx context.Context, prefix string) (_ *dto.IssuedAPIKeyDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "APIKeyRotatorUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("APIKeyRotatorUseCase", err)
+	}()
+
+	current, err := findActiveAPIKey(ctx, usecase.apiKeyRepository, "APIKeyRotatorUseCase", prefix)
+	if err != nil {
+		logger.FromContext(ctx).Error("APIKeyRotatorUseCase", "Execute", err, "api key cannot be rotated")
+		return nil, err
+	}
+
+	now := time.Now()
+	replacement := &model.APIKey{
+		Name:      current.Name,
+		Role:      current.Role,
+		OwnerRut:  current.OwnerRut,
+		Scopes:    current.Scopes,
+		ExpiresAt: current.ExpiresAt,
+		CreatedAt: now,
+	}
+
+	plaintext, err := issueAPIKey(ctx, usecase.apiKeyRepository, replacement)
+	if err != nil {
+		logger.FromContext(ctx).Error("APIKeyRotatorUseCase", "Execute", err, "failed to save replacement api key")
+		return nil, err
+	}
+
+	current.Revoke(now)
+	if err := usecase.apiKeyRepository.Update(ctx, current); err != nil {
+		logger.FromContext(ctx).Error("APIKeyRotatorUseCase", "Execute", err, "failed to revoke rotated api key")
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("APIKeyRotatorUseCase", "Execute", "api key "+prefix+" rotated to "+replacement.Prefix)
+	return &dto.IssuedAPIKeyDTO{APIKeyDTO: *mapper.ToAPIKeyDTO(replacement), Key: plaintext}, nil
+}
+
+func findActiveAPIKey(ctx context.Context, apiKeyRepository repositories.APIKeyRepository, component, prefix string) (*model.APIKey, error) {
+	if prefix == "" {
+		return nil, errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			component,
+			"findActiveAPIKey",
+			"prefix is required",
+		)
+	}
+
+	key, err := apiKeyRepository.FindByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, errorInfo.NewAppError(
+			errorInfo.ErrNotFound,
+			component,
+			"findActiveAPIKey",
+			"api key not found",
+		)
+	}
+
+	if key.IsRevoked() {
+		return nil, errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			component,
+			"findActiveAPIKey",
+			"api key is already revoked",
+		)
+	}
+	return key, nil
+}
@@ -0,0 +1,95 @@
+package implementations
+
+import (
+	"context"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	"license-service/internal/domain/repositories"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"time"
+)
+
+// EmployerAcknowledgerUseCase registra que RR.HH. de un empleador recibió la
+// licencia. Es idempotente: una segunda confirmación conserva la primera fecha.
+type EmployerAcknowledgerUseCase struct {
+	licenseRepository repositories.LicenseRepository
+	now               func() time.Time
+}
+
+func NewEmployerAcknowledgerUseCase(licenseRepository repositories.LicenseRepository) contrats.EmployerAcknowledger {
+	return &EmployerAcknowledgerUseCase{
+		licenseRepository: licenseRepository,
+		now:               time.Now,
+	}
+}
+
+func (usecase *EmployerAcknowledgerUseCase) Execute(ctx context.Context, folio string, employerRut string, expectedRevision int) (_ *dto.LicenseDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "EmployerAcknowledgerUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("EmployerAcknowledgerUseCase", err)
+	}()
+
+	logger.FromContext(ctx).Info("EmployerAcknowledgerUseCase", "Execute", "acknowledging folio: "+folio+" for employer: "+employerRut)
+
+	if folio == "" || employerRut == "" {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			"EmployerAcknowledgerUseCase",
+			"Execute",
+			"folio and employer RUT are required",
+		)
+		logger.FromContext(ctx).Error("EmployerAcknowledgerUseCase", "Execute", appErr, "empty folio or employer RUT provided")
+		return nil, appErr
+	}
+
+	license, err := usecase.licenseRepository.FindByFolio(ctx, folio)
+	if err != nil {
+		logger.FromContext(ctx).Error("EmployerAcknowledgerUseCase", "Execute", err, "failed to retrieve license from repository")
+		return nil, err
+	}
+
+	if license == nil {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrNotFound,
+			"EmployerAcknowledgerUseCase",
+			"Execute",
+			"license not found",
+		)
+		logger.FromContext(ctx).Info("EmployerAcknowledgerUseCase", "Execute", "license not found for folio: "+folio)
+		return nil, appErr
+	}
+
+	employer := license.Employer(employerRut)
+	if employer == nil {
+		// Igual que una licencia inexistente: no revela licencias de otros empleadores.
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrNotFound,
+			"EmployerAcknowledgerUseCase",
+			"Execute",
+			"license not found",
+		)
+		logger.FromContext(ctx).Info("EmployerAcknowledgerUseCase", "Execute", "folio "+folio+" is not delivered to employer: "+employerRut)
+		return nil, appErr
+	}
+
+	if err := license.CheckRevision(expectedRevision); err != nil {
+		logger.FromContext(ctx).Error("EmployerAcknowledgerUseCase", "Execute", err, "stale revision for folio: "+folio)
+		return nil, err
+	}
+
+	if !employer.IsAcknowledged() {
+		employer.Acknowledge(usecase.now())
+		if err := usecase.licenseRepository.UpdateEmployer(ctx, license, *employer); err != nil {
+			logger.FromContext(ctx).Error("EmployerAcknowledgerUseCase", "Execute", err, "failed to save acknowledgment")
+			return nil, err
+		}
+	}
+
+	logger.FromContext(ctx).Info("EmployerAcknowledgerUseCase", "Execute", "license acknowledged by employer: "+employerRut)
+	return mapper.ToLicenseDTO(license), nil
+}
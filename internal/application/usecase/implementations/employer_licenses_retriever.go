@@ -0,0 +1,72 @@
+package implementations
+
+import (
+	"context"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	model "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	valueobject "license-service/internal/domain/valueobject"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+)
+
+type EmployerLicensesRetrieverUseCase struct {
+	licenseRepository repositories.LicenseRepository
+}
+
+func NewEmployerLicensesRetrieverUseCase(licenseRepository repositories.LicenseRepository) contrats.EmployerLicensesRetriever {
+	return &EmployerLicensesRetrieverUseCase{
+		licenseRepository: licenseRepository,
+	}
+}
+
+func (usecase *EmployerLicensesRetrieverUseCase) Execute(ctx context.Context, filter dto.EmployerLicenseFilterDTO) (_ []*dto.LicenseDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "EmployerLicensesRetrieverUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("EmployerLicensesRetrieverUseCase", err)
+	}()
+
+	logger.FromContext(ctx).Info("EmployerLicensesRetrieverUseCase", "Execute", "retrieving licenses for employer: "+filter.EmployerRut)
+
+	if _, err = valueobject.NewRut(filter.EmployerRut); err != nil {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			"EmployerLicensesRetrieverUseCase",
+			"Execute",
+			"invalid employer RUT format",
+		)
+		logger.FromContext(ctx).Error("EmployerLicensesRetrieverUseCase", "Execute", appErr, "invalid employer RUT")
+		return nil, appErr
+	}
+
+	if filter.Status != "" && !model.IsKnownStatus(filter.Status) {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			"EmployerLicensesRetrieverUseCase",
+			"Execute",
+			"unknown license status: "+filter.Status,
+		)
+		logger.FromContext(ctx).Error("EmployerLicensesRetrieverUseCase", "Execute", appErr, "invalid status filter")
+		return nil, appErr
+	}
+
+	licenses, err := usecase.licenseRepository.FindByCriteria(ctx, repositories.LicenseCriteria{
+		EmployerRut:  filter.EmployerRut,
+		Status:       filter.Status,
+		Acknowledged: filter.Acknowledged,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("EmployerLicensesRetrieverUseCase", "Execute", err, "failed to retrieve licenses from repository")
+		return nil, err
+	}
+
+	licenseDTOs := mapper.ToLicenseDTOs(licenses)
+
+	logger.FromContext(ctx).Info("EmployerLicensesRetrieverUseCase", "Execute", "retrieved licenses successfully for employer: "+filter.EmployerRut, "count", len(licenseDTOs))
+	return licenseDTOs, nil
+}
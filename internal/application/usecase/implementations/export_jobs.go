@@ -0,0 +1,287 @@
+package implementations
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	model "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	"license-service/pkg/auth"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"time"
+)
+
+// ExportJobStarterUseCase registra una exportación asíncrona y la lanza en
+// segundo plano con launch; el archivo queda en el ExportFileStore.
+type ExportJobStarterUseCase struct {
+	exporter *LicenseExporterUseCase
+	jobs     repositories.ExportJobRepository
+	files    repositories.ExportFileStore
+	launch   func(func(context.Context))
+	slots    chan struct{}
+	jobTTL   time.Duration
+	now      func() time.Time
+}
+
+func NewExportJobStarterUseCase(
+	licenseRepository repositories.LicenseRepository,
+	jobs repositories.ExportJobRepository,
+	files repositories.ExportFileStore,
+	launch func(func(context.Context)),
+	settings ExportSettings,
+) contrats.ExportJobStarter {
+	return &ExportJobStarterUseCase{
+		exporter: newLicenseExporter(licenseRepository, settings),
+		jobs:     jobs,
+		files:    files,
+		launch:   launch,
+		slots:    make(chan struct{}, settings.MaxRunningJobs),
+		jobTTL:   settings.JobTTL,
+		now:      time.Now,
+	}
+}
+
+func (usecase *ExportJobStarterUseCase) Execute(ctx context.Context, request dto.ExportRequestDTO) (_ *dto.ExportJobDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "ExportJobStarterUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("ExportJobStarterUseCase", err)
+	}()
+
+	plan, err := usecase.exporter.plan(request)
+	if err != nil {
+		logger.FromContext(ctx).Error("ExportJobStarterUseCase", "Execute", err, "export request rejected")
+		return nil, err
+	}
+
+	id, err := newExportJobID()
+	if err != nil {
+		appErr := errorInfo.WrapError(errorInfo.ErrInternalError, "ExportJobStarterUseCase", "Execute", "failed to generate export job id", err)
+		logger.FromContext(ctx).Error("ExportJobStarterUseCase", "Execute", appErr, "export job not created")
+		return nil, appErr
+	}
+
+	job := model.NewExportJob(id, plan.format, exportRequester(ctx), usecase.now())
+	if err = usecase.jobs.Save(ctx, job); err != nil {
+		logger.FromContext(ctx).Error("ExportJobStarterUseCase", "Execute", err, "failed to save export job")
+		return nil, err
+	}
+
+	// El trabajo corre con el contexto del servicio, no con el del pedido, pero
+	// conserva sus campos de log para poder seguirlo.
+	entry := logger.FromContext(ctx)
+	usecase.launch(func(runCtx context.Context) {
+		usecase.run(logger.NewContext(runCtx, entry), *job, plan)
+	})
+
+	logger.FromContext(ctx).Info("ExportJobStarterUseCase", "Execute", "export job queued: "+id)
+	return mapper.ToExportJobDTO(job), nil
+}
+
+// run espera un turno entre las exportaciones en curso y escribe el archivo.
+// Si el servicio se detiene antes de terminar, el trabajo queda fallido.
+func (usecase *ExportJobStarterUseCase) run(ctx context.Context, job model.ExportJob, plan *exportPlan) {
+	select {
+	case usecase.slots <- struct{}{}:
+		defer func() { <-usecase.slots }()
+	case <-ctx.Done():
+		usecase.finish(ctx, &job, 0, errorInfo.WrapError(errorInfo.ErrInternalError, "ExportJobStarterUseCase", "run", "export was cancelled before it started", ctx.Err()))
+		return
+	}
+
+	job.Start()
+	if err := usecase.jobs.Update(ctx, &job); err != nil {
+		logger.FromContext(ctx).Error("ExportJobStarterUseCase", "run", err, "failed to mark export job as running: "+job.ID)
+	}
+
+	name := exportFileName(&job)
+	file, err := usecase.files.Create(ctx, name)
+	if err != nil {
+		usecase.finish(ctx, &job, 0, err)
+		return
+	}
+
+	rows, err := usecase.exporter.export(ctx, plan, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = errorInfo.WrapError(errorInfo.ErrInternalError, "ExportJobStarterUseCase", "run", "export file could not be written", closeErr)
+	}
+	if err != nil {
+		if deleteErr := usecase.files.Delete(context.WithoutCancel(ctx), name); deleteErr != nil {
+			logger.FromContext(ctx).Error("ExportJobStarterUseCase", "run", deleteErr, "failed to delete incomplete export file: "+name)
+		}
+	}
+	usecase.finish(ctx, &job, rows, err)
+}
+
+// finish guarda el resultado aunque el contexto ya esté cancelado.
+func (usecase *ExportJobStarterUseCase) finish(ctx context.Context, job *model.ExportJob, rows int, err error) {
+	if err != nil {
+		itemErr := mapper.ToItemErrorDTO(err)
+		job.Fail(itemErr.Code, itemErr.Message, usecase.now(), usecase.jobTTL)
+		logger.FromContext(ctx).Error("ExportJobStarterUseCase", "run", err, "export job failed: "+job.ID)
+	} else {
+		job.Succeed(rows, usecase.now(), usecase.jobTTL)
+		logger.FromContext(ctx).Info("ExportJobStarterUseCase", "run", fmt.Sprintf("export job %s finished with %d licenses", job.ID, rows))
+	}
+
+	if updateErr := usecase.jobs.Update(context.WithoutCancel(ctx), job); updateErr != nil {
+		logger.FromContext(ctx).Error("ExportJobStarterUseCase", "run", updateErr, "failed to save export job result: "+job.ID)
+	}
+}
+
+// ExportJobRetrieverUseCase devuelve el estado de una exportación asíncrona a
+// quien la pidió.
+type ExportJobRetrieverUseCase struct {
+	jobs repositories.ExportJobRepository
+	now  func() time.Time
+}
+
+func NewExportJobRetrieverUseCase(jobs repositories.ExportJobRepository) contrats.ExportJobRetriever {
+	return &ExportJobRetrieverUseCase{jobs: jobs, now: time.Now}
+}
+
+func (usecase *ExportJobRetrieverUseCase) Execute(ctx context.Context, id string) (_ *dto.ExportJobDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "ExportJobRetrieverUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("ExportJobRetrieverUseCase", err)
+	}()
+
+	job, err := findExportJob(ctx, usecase.jobs, id, usecase.now(), "ExportJobRetrieverUseCase")
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToExportJobDTO(job), nil
+}
+
+// ExportJobDownloaderUseCase abre el archivo de una exportación terminada.
+type ExportJobDownloaderUseCase struct {
+	jobs  repositories.ExportJobRepository
+	files repositories.ExportFileStore
+	now   func() time.Time
+}
+
+func NewExportJobDownloaderUseCase(jobs repositories.ExportJobRepository, files repositories.ExportFileStore) contrats.ExportJobDownloader {
+	return &ExportJobDownloaderUseCase{jobs: jobs, files: files, now: time.Now}
+}
+
+func (usecase *ExportJobDownloaderUseCase) Execute(ctx context.Context, id string) (_ *dto.ExportFileDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "ExportJobDownloaderUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("ExportJobDownloaderUseCase", err)
+	}()
+
+	job, err := findExportJob(ctx, usecase.jobs, id, usecase.now(), "ExportJobDownloaderUseCase")
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != model.ExportJobSucceeded {
+		appErr := errorInfo.NewAppError(errorInfo.ErrConflict, "ExportJobDownloaderUseCase", "Execute", "export job is "+job.Status)
+		logger.FromContext(ctx).Warn("ExportJobDownloaderUseCase", "Execute", "export job not ready for download: "+id)
+		return nil, appErr
+	}
+
+	content, err := usecase.files.Open(ctx, exportFileName(job))
+	if err != nil {
+		logger.FromContext(ctx).Error("ExportJobDownloaderUseCase", "Execute", err, "failed to open export file: "+id)
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("ExportJobDownloaderUseCase", "Execute", "downloading export job: "+id)
+	return &dto.ExportFileDTO{
+		Name:        "licenses-" + job.ID + "." + job.Format,
+		ContentType: dto.ExportContentType(job.Format),
+		Content:     content,
+	}, nil
+}
+
+// ExportJobPurgerUseCase descarta las exportaciones vencidas y sus archivos. Un
+// trabajo que no terminó en jobTTL se da por abandonado: su proceso se detuvo
+// sin poder marcarlo fallido.
+type ExportJobPurgerUseCase struct {
+	jobs   repositories.ExportJobRepository
+	files  repositories.ExportFileStore
+	jobTTL time.Duration
+}
+
+func NewExportJobPurgerUseCase(jobs repositories.ExportJobRepository, files repositories.ExportFileStore, jobTTL time.Duration) contrats.ExportJobPurger {
+	return &ExportJobPurgerUseCase{jobs: jobs, files: files, jobTTL: jobTTL}
+}
+
+func (usecase *ExportJobPurgerUseCase) Execute(ctx context.Context, now time.Time) (_ int, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "ExportJobPurgerUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("ExportJobPurgerUseCase", err)
+	}()
+
+	cutoff := now.Add(-usecase.jobTTL)
+	expired, err := usecase.jobs.DeleteExpired(ctx, now, cutoff)
+	if err != nil {
+		logger.FromContext(ctx).Error("ExportJobPurgerUseCase", "Execute", err, "failed to delete expired export jobs")
+		return 0, err
+	}
+
+	// Un trabajo abandonado pudo dejar un archivo a medias.
+	for _, job := range expired {
+		if err := usecase.files.Delete(ctx, exportFileName(job)); err != nil {
+			logger.FromContext(ctx).Error("ExportJobPurgerUseCase", "Execute", err, "failed to delete export file: "+job.ID)
+		}
+	}
+
+	// Los archivos de otra réplica o de un trabajo ya borrado no aparecen en la
+	// base; se descartan por antigüedad con el mismo plazo.
+	orphans, err := usecase.files.DeleteOlderThan(ctx, cutoff)
+	if err != nil {
+		logger.FromContext(ctx).Error("ExportJobPurgerUseCase", "Execute", err, "failed to delete old export files")
+	}
+
+	if len(expired) > 0 || orphans > 0 {
+		logger.FromContext(ctx).Info("ExportJobPurgerUseCase", "Execute", fmt.Sprintf("purged %d expired export jobs and %d old export files", len(expired), orphans))
+	}
+	return len(expired), nil
+}
+
+// findExportJob responde "no encontrado" también para los trabajos de otra
+// identidad y los vencidos, sin revelar que existen.
+func findExportJob(ctx context.Context, jobs repositories.ExportJobRepository, id string, now time.Time, component string) (*model.ExportJob, error) {
+	job, err := jobs.FindByID(ctx, id)
+	if err != nil {
+		logger.FromContext(ctx).Error(component, "Execute", err, "failed to retrieve export job: "+id)
+		return nil, err
+	}
+	if job == nil || !job.IsVisibleTo(exportRequester(ctx)) || job.IsExpiredAt(now) {
+		appErr := errorInfo.NewAppError(errorInfo.ErrNotFound, component, "Execute", "export job not found")
+		logger.FromContext(ctx).Warn(component, "Execute", "export job not found: "+id)
+		return nil, appErr
+	}
+	return job, nil
+}
+
+// exportRequester es el subject autenticado, o vacío sin autenticación.
+func exportRequester(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		return principal.Subject
+	}
+	return ""
+}
+
+func exportFileName(job *model.ExportJob) string {
+	return job.ID + "." + job.Format
+}
+
+func newExportJobID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -2,68 +2,143 @@ package implementations
 
 import (
 	"context"
+	"fmt"
 	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
 	"license-service/internal/application/usecase/contrats"
 	model "license-service/internal/domain/model"
 	"license-service/internal/domain/repositories"
 	valueobject "license-service/internal/domain/valueobject"
+	"license-service/pkg/auth"
 	errorInfo "license-service/pkg/log/error"
 	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"strings"
+	"time"
 )
 
 type IssueLicenseUseCase struct {
 	licenseRepository repositories.LicenseRepository
-	logger            logger.Logger
 }
 
 func NewIssueLicenseUseCase(licenseRepository repositories.LicenseRepository) contrats.LicenseIssuer {
 	return &IssueLicenseUseCase{
 		licenseRepository: licenseRepository,
-		logger:            *logger.NewLogger(),
 	}
 }
 
-func (usecase *IssueLicenseUseCase) Execute(ctx context.Context, createLicenseDTO dto.CreateLicenseDTO) (*dto.LicenseDTO, error) {
+func (usecase *IssueLicenseUseCase) Execute(ctx context.Context, createLicenseDTO dto.CreateLicenseDTO) (_ *dto.LicenseDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "IssueLicenseUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("IssueLicenseUseCase", err)
+	}()
 
-	if err := usecase.validateRequiredFields(createLicenseDTO); err != nil {
-		usecase.logger.Error("IssueLicenseUseCase", "Execute", err, "validation failed")
+	createLicenseDTO, err = usecase.resolveIssuingDoctor(ctx, createLicenseDTO)
+	if err != nil {
+		logger.FromContext(ctx).Error("IssueLicenseUseCase", "Execute", err, "issuing doctor could not be resolved")
+		return nil, err
+	}
+
+	license, err := buildLicense(createLicenseDTO, time.Now())
+	if err != nil {
+		logger.FromContext(ctx).Error("IssueLicenseUseCase", "Execute", err, "license validation failed")
+		return nil, err
+	}
+	license.GenerateFolio()
+
+	if err := usecase.licenseRepository.Save(ctx, license); err != nil {
+		logger.FromContext(ctx).Error("IssueLicenseUseCase", "Execute", err, "failed to save license")
+		return nil, err
+	}
+	metrics.LicensesIssuedTotal.Inc()
+
+	responseDTO := mapper.ToLicenseDTO(license)
+
+	logger.FromContext(ctx).Info("IssueLicenseUseCase", "Execute", "license created successfully")
+	return responseDTO, nil
+}
+
+// buildLicense valida un pedido de emisión con los value objects de la licencia
+// y la arma emitida en issuedAt, todavía sin folio. La usan la emisión y la
+// importación masiva para que ambas acepten exactamente lo mismo.
+func buildLicense(createLicenseDTO dto.CreateLicenseDTO, issuedAt time.Time) (*model.License, error) {
+	if err := validateRequiredFields(createLicenseDTO); err != nil {
 		return nil, err
 	}
 
 	patientID, err := valueobject.NewRut(createLicenseDTO.PatientID)
 	if err != nil {
-		AppErr := errorInfo.NewAppError(
+		return nil, errorInfo.NewAppError(
 			errorInfo.ErrInvalidData,
 			"IssueLicenseUseCase",
-			"Execute",
+			"buildLicense",
 			"invalid PatientID format",
 		)
-		usecase.logger.Error("IssueLicenseUseCase", "Execute", AppErr, "invalid PatientID")
-		return nil, AppErr
 	}
 
 	doctorID, err := valueobject.NewDoctorID(createLicenseDTO.DoctorID)
 	if err != nil {
-		AppErr := errorInfo.NewAppError(
+		return nil, errorInfo.NewAppError(
 			errorInfo.ErrInvalidData,
 			"IssueLicenseUseCase",
-			"Execute",
+			"buildLicense",
 			"invalid DoctorID format",
 		)
-		usecase.logger.Error("IssueLicenseUseCase", "Execute", AppErr, "invalid DoctorID")
-		return nil, AppErr
 	}
 
 	diagnosis, err := valueobject.NewDiagnosis(createLicenseDTO.Diagnosis)
 	if err != nil {
-		AppErr := errorInfo.NewAppError(
+		return nil, errorInfo.NewAppError(
 			errorInfo.ErrInvalidData,
 			"IssueLicenseUseCase",
-			"Execute",
+			"buildLicense",
 			"invalid Diagnosis format",
 		)
-		usecase.logger.Error("IssueLicenseUseCase", "Execute", AppErr, "invalid Diagnosis")
-		return nil, AppErr
+	}
+
+	licenseType, err := valueobject.NewLicenseType(createLicenseDTO.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	dueDate, err := validateTypeRules(*licenseType, createLicenseDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	restType, err := valueobject.NewRestType(createLicenseDTO.RestType)
+	if err != nil {
+		return nil, err
+	}
+
+	restLocation, err := valueobject.NewRestLocation(createLicenseDTO.RestLocation, createLicenseDTO.RestAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateRestForType(licenseType.Value(), *restType); err != nil {
+		return nil, err
+	}
+
+	employers, err := buildEmployers(createLicenseDTO.EmployerRuts)
+	if err != nil {
+		return nil, err
+	}
+
+	insurerRut := ""
+	if createLicenseDTO.InsurerRut != "" {
+		insurer, err := valueobject.NewRut(createLicenseDTO.InsurerRut)
+		if err != nil {
+			return nil, errorInfo.NewAppError(
+				errorInfo.ErrInvalidData,
+				"IssueLicenseUseCase",
+				"buildLicense",
+				"invalid InsurerRut format",
+			)
+		}
+		insurerRut = insurer.Value()
 	}
 
 	license := model.NewLicense(
@@ -74,41 +149,60 @@ func (usecase *IssueLicenseUseCase) Execute(ctx context.Context, createLicenseDT
 			Status:    model.StatusIssued,
 			StartDate: createLicenseDTO.StartDate.Time,
 			Days:      createLicenseDTO.Days,
+			IssuedAt:  issuedAt,
+			Type:      licenseType.Value(),
+			Payer:     licenseType.Payer(),
+			DueDate:   dueDate,
+			ChildRut:  createLicenseDTO.ChildRut,
+
+			RestType:     restType.Value(),
+			RestLocation: restLocation.Value(),
+			RestAddress:  restLocation.Address(),
+			Employers:    employers,
+			InsurerRut:   insurerRut,
+			Adjudication: model.NewAdjudication(),
+			Version:      1,
+			Revision:     1,
 		},
 	)
-	license.GenerateFolio()
 
-	if err := license.IsValid(); err != nil {
-		AppErr := errorInfo.NewAppError(
+	if license.IsValid() != nil {
+		return nil, errorInfo.NewAppError(
 			errorInfo.ErrInvalidData,
 			"IssueLicenseUseCase",
-			"Execute",
+			"buildLicense",
 			"license is not valid",
 		)
-		usecase.logger.Error("IssueLicenseUseCase", "Execute", AppErr, err.Error())
-		return nil, AppErr
 	}
 
-	if err := usecase.licenseRepository.Save(ctx, license); err != nil {
-		usecase.logger.Error("IssueLicenseUseCase", "Execute", err, "failed to save license")
-		return nil, err
+	return license, nil
+}
+
+// resolveIssuingDoctor toma el médico emisor del token autenticado; el doctorId
+// del body solo se respeta cuando la autenticación está deshabilitada.
+func (usecase *IssueLicenseUseCase) resolveIssuingDoctor(ctx context.Context, createLicenseDTO dto.CreateLicenseDTO) (dto.CreateLicenseDTO, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return createLicenseDTO, nil
 	}
 
-	responseDTO := &dto.LicenseDTO{
-		Folio:     license.Folio,
-		PatientID: license.PatientID,
-		DoctorID:  license.DoctorID,
-		Diagnosis: license.Diagnosis,
-		StartDate: license.StartDate.String(),
-		Days:      license.Days,
-		Status:    license.Status,
+	if principal.DoctorID == "" {
+		return createLicenseDTO, errorInfo.NewAppError(
+			errorInfo.ErrForbidden,
+			"IssueLicenseUseCase",
+			"resolveIssuingDoctor",
+			"authenticated principal is not a doctor",
+		)
 	}
 
-	usecase.logger.Info("IssueLicenseUseCase", "Execute", "license created successfully")
-	return responseDTO, nil
+	if createLicenseDTO.DoctorID != "" && createLicenseDTO.DoctorID != principal.DoctorID {
+		logger.FromContext(ctx).Warn("IssueLicenseUseCase", "resolveIssuingDoctor", "ignoring doctorId from body in favour of token subject "+principal.Subject)
+	}
+	createLicenseDTO.DoctorID = principal.DoctorID
+	return createLicenseDTO, nil
 }
 
-func (usecase *IssueLicenseUseCase) validateRequiredFields(dto dto.CreateLicenseDTO) error {
+func validateRequiredFields(dto dto.CreateLicenseDTO) error {
 	if dto.PatientID == "" {
 		return errorInfo.NewAppError(
 			errorInfo.ErrMissingRequiredField,
@@ -147,3 +241,115 @@ func (usecase *IssueLicenseUseCase) validateRequiredFields(dto dto.CreateLicense
 
 	return nil
 }
+
+// buildEmployers valida los RUT de los empleadores y descarta los repetidos.
+func buildEmployers(employerRuts []string) ([]model.Employer, error) {
+	if len(employerRuts) == 0 {
+		return nil, nil
+	}
+
+	employers := make([]model.Employer, 0, len(employerRuts))
+	seen := make(map[string]bool, len(employerRuts))
+	for _, value := range employerRuts {
+		employerRut, err := valueobject.NewRut(strings.TrimSpace(value))
+		if err != nil {
+			return nil, errorInfo.NewAppError(
+				errorInfo.ErrInvalidData,
+				"IssueLicenseUseCase",
+				"buildEmployers",
+				"invalid employer RUT format: "+value,
+			)
+		}
+		if seen[employerRut.Value()] {
+			continue
+		}
+		seen[employerRut.Value()] = true
+		employers = append(employers, *model.NewEmployer(employerRut.Value()))
+	}
+	return employers, nil
+}
+
+// validateRestForType comprueba que el tipo de reposo sea compatible con el
+// tipo de licencia: maternidad solo admite reposo total.
+func validateRestForType(licenseType string, restType valueobject.RestType) error {
+	if licenseType == valueobject.LicenseTypeMaternity && restType.Value() == valueobject.RestTypePartial {
+		return errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			"IssueLicenseUseCase",
+			"validateRestForType",
+			"maternity licenses require total rest",
+		)
+	}
+	return nil
+}
+
+// validateTypeRules aplica las reglas propias de cada tipo de licencia: duración
+// máxima, fecha probable de parto para maternidad y RUT del hijo para
+// enfermedad grave del hijo menor de un año.
+func validateTypeRules(licenseType valueobject.LicenseType, dto dto.CreateLicenseDTO) (*time.Time, error) {
+	if dto.Days > licenseType.MaxDays() {
+		return nil, errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			"IssueLicenseUseCase",
+			"validateTypeRules",
+			fmt.Sprintf("%s licenses cannot exceed %d days", licenseType, licenseType.MaxDays()),
+		)
+	}
+
+	var dueDate *time.Time
+	switch {
+	case licenseType.RequiresDueDate() && dto.DueDate == nil:
+		return nil, errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			"IssueLicenseUseCase",
+			"validateTypeRules",
+			"dueDate is required for "+licenseType.Value()+" licenses",
+		)
+	case licenseType.RequiresDueDate():
+		earliestStart := dto.DueDate.Time.AddDate(0, 0, -valueobject.MaternityPrenatalDays)
+		if dto.StartDate.Time.Before(earliestStart) {
+			return nil, errorInfo.NewAppError(
+				errorInfo.ErrInvalidData,
+				"IssueLicenseUseCase",
+				"validateTypeRules",
+				fmt.Sprintf("maternity licenses cannot start more than %d days before the due date", valueobject.MaternityPrenatalDays),
+			)
+		}
+		dueDate = &dto.DueDate.Time
+	case dto.DueDate != nil:
+		return nil, errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			"IssueLicenseUseCase",
+			"validateTypeRules",
+			"dueDate only applies to maternity licenses",
+		)
+	}
+
+	switch {
+	case licenseType.RequiresChildRut() && dto.ChildRut == "":
+		return nil, errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			"IssueLicenseUseCase",
+			"validateTypeRules",
+			"childRut is required for "+licenseType.Value()+" licenses",
+		)
+	case licenseType.RequiresChildRut():
+		if _, err := valueobject.NewRut(dto.ChildRut); err != nil || dto.ChildRut == dto.PatientID {
+			return nil, errorInfo.NewAppError(
+				errorInfo.ErrInvalidData,
+				"IssueLicenseUseCase",
+				"validateTypeRules",
+				"invalid childRut",
+			)
+		}
+	case dto.ChildRut != "":
+		return nil, errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			"IssueLicenseUseCase",
+			"validateTypeRules",
+			"childRut only applies to sick_child licenses",
+		)
+	}
+
+	return dueDate, nil
+}
@@ -0,0 +1,194 @@
+package implementations
+
+import (
+	"context"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	model "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	valueobject "license-service/internal/domain/valueobject"
+	"license-service/pkg/auth"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"time"
+)
+
+// LicenseAmenderUseCase corrige el diagnóstico o el reposo de una licencia
+// emitida. Cada enmienda queda como una nueva versión inmutable con el mismo folio.
+type LicenseAmenderUseCase struct {
+	licenseRepository repositories.LicenseRepository
+	now               func() time.Time
+}
+
+func NewLicenseAmenderUseCase(licenseRepository repositories.LicenseRepository) contrats.LicenseAmender {
+	return &LicenseAmenderUseCase{
+		licenseRepository: licenseRepository,
+		now:               time.Now,
+	}
+}
+
+func (usecase *LicenseAmenderUseCase) Execute(ctx context.Context, folio string, amendment dto.AmendLicenseDTO, expectedRevision int) (_ *dto.LicenseDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicenseAmenderUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicenseAmenderUseCase", err)
+	}()
+
+	logger.FromContext(ctx).Info("LicenseAmenderUseCase", "Execute", "amending license with folio: "+folio)
+
+	if folio == "" {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			"LicenseAmenderUseCase",
+			"Execute",
+			"folio is required",
+		)
+		logger.FromContext(ctx).Error("LicenseAmenderUseCase", "Execute", appErr, "empty folio provided")
+		return nil, appErr
+	}
+
+	license, err := usecase.licenseRepository.FindByFolio(ctx, folio)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseAmenderUseCase", "Execute", err, "failed to retrieve license from repository")
+		return nil, err
+	}
+
+	if license == nil {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrNotFound,
+			"LicenseAmenderUseCase",
+			"Execute",
+			"license not found",
+		)
+		logger.FromContext(ctx).Info("LicenseAmenderUseCase", "Execute", "license not found for folio: "+folio)
+		return nil, appErr
+	}
+
+	if err := license.CheckRevision(expectedRevision); err != nil {
+		logger.FromContext(ctx).Error("LicenseAmenderUseCase", "Execute", err, "stale revision for folio: "+folio)
+		return nil, err
+	}
+
+	author, err := usecase.resolveAuthor(ctx, license, amendment)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseAmenderUseCase", "Execute", err, "amendment author could not be resolved")
+		return nil, err
+	}
+
+	amended, err := usecase.applyAmendment(*license, amendment)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseAmenderUseCase", "Execute", err, "invalid amendment")
+		return nil, err
+	}
+
+	// Las licencias emitidas antes del historial no tienen su versión 1 guardada:
+	// se guarda junto con la primera enmienda, antes de modificar la licencia.
+	var versions []*model.LicenseVersion
+	issuedVersion, err := usecase.licenseRepository.FindVersion(ctx, folio, 1)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseAmenderUseCase", "Execute", err, "failed to retrieve issued version")
+		return nil, err
+	}
+	if issuedVersion == nil {
+		versions = append(versions, license.IssuedVersion())
+	}
+
+	version, err := license.Amend(amended, author, amendment.Reason, usecase.now())
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseAmenderUseCase", "Execute", err, "amendment rejected for folio: "+folio)
+		return nil, err
+	}
+	versions = append(versions, version)
+
+	if err := usecase.licenseRepository.SaveAmendment(ctx, license, versions); err != nil {
+		logger.FromContext(ctx).Error("LicenseAmenderUseCase", "Execute", err, "failed to save amendment")
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("LicenseAmenderUseCase", "Execute", "license amended successfully", "folio", folio, "version", license.Version)
+	return mapper.ToLicenseDTO(license), nil
+}
+
+// resolveAuthor toma al médico que firma la enmienda del token (o del body con
+// la autenticación deshabilitada). Solo el médico emisor puede enmendar; un
+// administrador firma con su sujeto.
+func (usecase *LicenseAmenderUseCase) resolveAuthor(ctx context.Context, license *model.License, amendment dto.AmendLicenseDTO) (string, error) {
+	doctorID := amendment.DoctorID
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if !principal.HasRole(auth.RoleDoctor) {
+			return principal.Subject, nil
+		}
+		doctorID = principal.DoctorID
+	}
+
+	if doctorID == "" {
+		return "", errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			"LicenseAmenderUseCase",
+			"resolveAuthor",
+			"DoctorID is required",
+		)
+	}
+	if doctorID != license.DoctorID {
+		return "", errorInfo.NewAppError(
+			errorInfo.ErrForbidden,
+			"LicenseAmenderUseCase",
+			"resolveAuthor",
+			"only the issuing doctor can amend a license",
+		)
+	}
+	return doctorID, nil
+}
+
+// applyAmendment valida los campos enmendados con las mismas reglas de la
+// emisión y devuelve la licencia resultante. Al dejar de reposar en "other" la
+// dirección se descarta si no se informa otra.
+func (usecase *LicenseAmenderUseCase) applyAmendment(amended model.License, amendment dto.AmendLicenseDTO) (model.License, error) {
+	amended.PatientID = amendment.PatientID
+
+	if amendment.Diagnosis != nil {
+		diagnosis, err := valueobject.NewDiagnosis(*amendment.Diagnosis)
+		if err != nil {
+			return amended, errorInfo.NewAppError(
+				errorInfo.ErrInvalidData,
+				"LicenseAmenderUseCase",
+				"applyAmendment",
+				"invalid Diagnosis format",
+			)
+		}
+		amended.Diagnosis = diagnosis.Value()
+	}
+
+	if amendment.RestType != nil {
+		amended.RestType = *amendment.RestType
+	}
+	restType, err := valueobject.NewRestType(amended.RestType)
+	if err != nil {
+		return amended, err
+	}
+	if err := validateRestForType(amended.Type, *restType); err != nil {
+		return amended, err
+	}
+
+	if amendment.RestLocation != nil {
+		amended.RestLocation = *amendment.RestLocation
+		if amended.RestLocation != valueobject.RestLocationOther {
+			amended.RestAddress = ""
+		}
+	}
+	if amendment.RestAddress != nil {
+		amended.RestAddress = *amendment.RestAddress
+	}
+	restLocation, err := valueobject.NewRestLocation(amended.RestLocation, amended.RestAddress)
+	if err != nil {
+		return amended, err
+	}
+
+	amended.RestType = restType.Value()
+	amended.RestLocation = restLocation.Value()
+	amended.RestAddress = restLocation.Address()
+	return amended, nil
+}
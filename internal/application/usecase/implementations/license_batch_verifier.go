@@ -0,0 +1,148 @@
+package implementations
+
+import (
+	"context"
+	"fmt"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	model "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	valueobject "license-service/internal/domain/valueobject"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"strings"
+	"time"
+)
+
+// LicenseBatchVerifierUseCase verifica varios folios con una sola consulta. Un
+// ítem inválido no hace fallar el lote: se informa con su propio error.
+type LicenseBatchVerifierUseCase struct {
+	licenseRepository repositories.LicenseRepository
+	maxItems          int
+	now               func() time.Time
+}
+
+func NewLicenseBatchVerifierUseCase(licenseRepository repositories.LicenseRepository, maxItems int) contrats.LicenseBatchVerifier {
+	return &LicenseBatchVerifierUseCase{
+		licenseRepository: licenseRepository,
+		maxItems:          maxItems,
+		now:               time.Now,
+	}
+}
+
+func (usecase *LicenseBatchVerifierUseCase) Execute(ctx context.Context, request dto.BatchVerificationRequestDTO) (_ *dto.BatchVerificationDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicenseBatchVerifierUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicenseBatchVerifierUseCase", err)
+	}()
+
+	logger.FromContext(ctx).Info("LicenseBatchVerifierUseCase", "Execute", fmt.Sprintf("verifying batch of %d folios", len(request.Items)))
+
+	if len(request.Items) == 0 {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			"LicenseBatchVerifierUseCase",
+			"Execute",
+			"items are required",
+		)
+		logger.FromContext(ctx).Error("LicenseBatchVerifierUseCase", "Execute", appErr, "empty batch")
+		return nil, appErr
+	}
+
+	if len(request.Items) > usecase.maxItems {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			"LicenseBatchVerifierUseCase",
+			"Execute",
+			fmt.Sprintf("a batch accepts at most %d folios", usecase.maxItems),
+		)
+		logger.FromContext(ctx).Error("LicenseBatchVerifierUseCase", "Execute", appErr, fmt.Sprintf("batch of %d folios rejected", len(request.Items)))
+		return nil, appErr
+	}
+
+	itemErrors := make([]error, len(request.Items))
+	folios := make([]string, 0, len(request.Items))
+	seen := make(map[string]bool, len(request.Items))
+	for i, item := range request.Items {
+		if itemErrors[i] = validateBatchItem(item, request.RequirePatientID); itemErrors[i] != nil {
+			continue
+		}
+		folio := strings.TrimSpace(item.Folio)
+		if !seen[folio] {
+			seen[folio] = true
+			folios = append(folios, folio)
+		}
+	}
+
+	licenses, err := usecase.licenseRepository.FindByFolios(ctx, folios)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseBatchVerifierUseCase", "Execute", err, "failed to retrieve licenses from repository")
+		return nil, err
+	}
+
+	byFolio := make(map[string]*model.License, len(licenses))
+	for _, license := range licenses {
+		byFolio[license.Folio] = license
+	}
+
+	now := usecase.now()
+	result := &dto.BatchVerificationDTO{
+		Results:   make([]dto.BatchVerificationResultDTO, 0, len(request.Items)),
+		CheckedAt: now.UTC().Format(time.RFC3339),
+	}
+	for i, item := range request.Items {
+		folio := strings.TrimSpace(item.Folio)
+		if itemErrors[i] != nil {
+			result.Results = append(result.Results, dto.BatchVerificationResultDTO{Folio: folio, Error: mapper.ToItemErrorDTO(itemErrors[i])})
+			continue
+		}
+
+		var verification *dto.LicenseVerificationDTO
+		license := byFolio[folio]
+		if license == nil || (item.PatientID != "" && !strings.EqualFold(license.PatientID, item.PatientID)) {
+			verification = mapper.ToNotFoundVerificationDTO(folio, now)
+		} else {
+			verification = mapper.ToLicenseVerificationDTO(license, now)
+		}
+		metrics.LicenseVerificationsTotal.WithLabelValues(verification.Reason).Inc()
+		result.Results = append(result.Results, dto.BatchVerificationResultDTO{Folio: folio, Verification: verification})
+	}
+
+	logger.FromContext(ctx).Info("LicenseBatchVerifierUseCase", "Execute", fmt.Sprintf("batch verified: %d items, %d licenses found", len(request.Items), len(licenses)))
+	return result, nil
+}
+
+func validateBatchItem(item dto.BatchVerificationItemDTO, requirePatientID bool) error {
+	if strings.TrimSpace(item.Folio) == "" {
+		return errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			"LicenseBatchVerifierUseCase",
+			"validateBatchItem",
+			"folio is required",
+		)
+	}
+	if requirePatientID && item.PatientID == "" {
+		return errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			"LicenseBatchVerifierUseCase",
+			"validateBatchItem",
+			"patientId is required",
+		)
+	}
+	if item.PatientID != "" {
+		if _, err := valueobject.NewRut(item.PatientID); err != nil {
+			return errorInfo.WrapError(
+				errorInfo.ErrInvalidData,
+				"LicenseBatchVerifierUseCase",
+				"validateBatchItem",
+				"patientId must be a RUT in format XXXXXXXX-X",
+				err,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package implementations
+
+import (
+	"context"
+	"fmt"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/usecase/contrats"
+	"license-service/internal/domain/repositories"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"time"
+)
+
+// LicenseExpirerUseCase marca como vencidas las licencias emitidas cuyo reposo
+// terminó antes de asOf.
+type LicenseExpirerUseCase struct {
+	licenseRepository repositories.LicenseRepository
+}
+
+func NewLicenseExpirerUseCase(licenseRepository repositories.LicenseRepository) contrats.LicenseExpirer {
+	return &LicenseExpirerUseCase{
+		licenseRepository: licenseRepository,
+	}
+}
+
+func (usecase *LicenseExpirerUseCase) Execute(ctx context.Context, asOf time.Time) (_ *dto.ExpirationSweepDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicenseExpirerUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicenseExpirerUseCase", err)
+	}()
+
+	today := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	logger.FromContext(ctx).Info("LicenseExpirerUseCase", "Execute", "expiring licenses ending before "+today.Format(dto.DateFormat))
+
+	candidates, err := usecase.licenseRepository.FindIssuedEndingBefore(ctx, today)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseExpirerUseCase", "Execute", err, "failed to find expirable licenses")
+		return nil, err
+	}
+
+	result := &dto.ExpirationSweepDTO{AsOf: today.Format(dto.DateFormat), Folios: []string{}}
+	for _, license := range candidates {
+		if !license.Expire(today) {
+			continue
+		}
+		if err = usecase.licenseRepository.UpdateStatus(ctx, license); err != nil {
+			// Otra escritura se adelantó (por ejemplo, una revocación): la
+			// licencia queda para el próximo barrido si sigue vencible.
+			if errorInfo.IsAppErrorCode(err, string(errorInfo.ErrOptimisticLockFailed)) {
+				logger.FromContext(ctx).Warn("LicenseExpirerUseCase", "Execute", "license changed during the sweep, skipping: "+license.Folio)
+				err = nil
+				continue
+			}
+			logger.FromContext(ctx).Error("LicenseExpirerUseCase", "Execute", err, "failed to expire license: "+license.Folio)
+			return result, err
+		}
+		metrics.LicensesExpiredTotal.Inc()
+		result.Folios = append(result.Folios, license.Folio)
+		result.Expired++
+	}
+
+	logger.FromContext(ctx).Info("LicenseExpirerUseCase", "Execute", fmt.Sprintf("expired %d licenses", result.Expired))
+	return result, nil
+}
@@ -0,0 +1,334 @@
+package implementations
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"license-service/internal/application/dto"
+	model "license-service/internal/domain/model"
+	errorInfo "license-service/pkg/log/error"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetRowGroupSize acota las filas que el escritor Parquet retiene en
+// memoria antes de volcar un row group.
+const parquetRowGroupSize = 10000
+
+type exportColumnKind int
+
+const (
+	exportString exportColumnKind = iota
+	exportInt
+	exportFloat
+	exportDate
+	exportTimestamp
+)
+
+// exportColumn es una columna exportable. value devuelve nil cuando la
+// licencia no tiene el dato, que se exporta vacío (CSV) o nulo.
+type exportColumn struct {
+	name  string
+	kind  exportColumnKind
+	value func(license *model.License) any
+}
+
+// exportColumns usa los nombres de campo del JSON de una licencia, con el
+// dictamen y los empleadores aplanados. Es el orden de las columnas cuando el
+// pedido no elige ninguna.
+var exportColumns = []exportColumn{
+	{"folio", exportString, func(l *model.License) any { return l.Folio }},
+	{"patientId", exportString, func(l *model.License) any { return l.PatientID }},
+	{"doctorId", exportString, func(l *model.License) any { return l.DoctorID }},
+	{"diagnosis", exportString, func(l *model.License) any { return l.Diagnosis }},
+	{"type", exportString, func(l *model.License) any { return l.Type }},
+	{"payer", exportString, func(l *model.License) any { return optionalString(l.Payer) }},
+	{"status", exportString, func(l *model.License) any { return l.Status }},
+	{"startDate", exportDate, func(l *model.License) any { return l.StartDate }},
+	{"endDate", exportDate, func(l *model.License) any { return l.EndDate() }},
+	{"days", exportInt, func(l *model.License) any { return int(l.Days) }},
+	{"effectiveDays", exportFloat, func(l *model.License) any { return l.EffectiveDays() }},
+	{"issuedAt", exportTimestamp, func(l *model.License) any { return l.IssuedAt }},
+	{"dueDate", exportDate, func(l *model.License) any { return optionalTime(l.DueDate) }},
+	{"childRut", exportString, func(l *model.License) any { return optionalString(l.ChildRut) }},
+	{"restType", exportString, func(l *model.License) any { return optionalString(l.RestType) }},
+	{"restLocation", exportString, func(l *model.License) any { return optionalString(l.RestLocation) }},
+	{"restAddress", exportString, func(l *model.License) any { return optionalString(l.RestAddress) }},
+	{"employerRuts", exportString, func(l *model.License) any {
+		ruts := make([]string, 0, len(l.Employers))
+		for _, employer := range l.Employers {
+			ruts = append(ruts, employer.Rut)
+		}
+		return optionalString(strings.Join(ruts, ";"))
+	}},
+	{"insurerRut", exportString, func(l *model.License) any { return optionalString(l.InsurerRut) }},
+	{"adjudicationStatus", exportString, func(l *model.License) any { return optionalString(l.Adjudication.Status) }},
+	{"approvedDays", exportInt, func(l *model.License) any {
+		if l.Adjudication.ApprovedDays == nil {
+			return nil
+		}
+		return int(*l.Adjudication.ApprovedDays)
+	}},
+	{"resolvedAt", exportTimestamp, func(l *model.License) any { return optionalTime(l.Adjudication.ResolvedAt) }},
+	{"version", exportInt, func(l *model.License) any { return l.Version }},
+	{"revision", exportInt, func(l *model.License) any { return l.CurrentRevision() }},
+}
+
+// selectExportColumns resuelve las columnas pedidas, en el orden pedido.
+func selectExportColumns(names []string) ([]exportColumn, error) {
+	if len(names) == 0 {
+		return exportColumns, nil
+	}
+
+	columns := make([]exportColumn, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		column, ok := findExportColumn(name)
+		if !ok {
+			return nil, errorInfo.NewAppError(errorInfo.ErrInvalidData, "LicenseExporterUseCase", "selectExportColumns", "unknown column: "+name)
+		}
+		if seen[name] {
+			return nil, errorInfo.NewAppError(errorInfo.ErrInvalidData, "LicenseExporterUseCase", "selectExportColumns", "repeated column: "+name)
+		}
+		seen[name] = true
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func findExportColumn(name string) (exportColumn, bool) {
+	for _, column := range exportColumns {
+		if column.name == name {
+			return column, true
+		}
+	}
+	return exportColumn{}, false
+}
+
+func optionalString(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func optionalTime(value *time.Time) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+// pseudonymizer reemplaza un diagnóstico por su HMAC-SHA256 truncado: el mismo
+// diagnóstico da siempre el mismo seudónimo con la misma clave, así se puede
+// agrupar por diagnóstico sin conocerlo.
+type pseudonymizer struct {
+	key []byte
+}
+
+func (p pseudonymizer) pseudonym(value string) string {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// licenseRowWriter escribe filas de valores en el orden de sus columnas. Close
+// completa el archivo, pero no cierra la salida.
+type licenseRowWriter interface {
+	Write(values []any) error
+	Close() error
+}
+
+func newLicenseRowWriter(format string, output io.Writer, columns []exportColumn) (licenseRowWriter, error) {
+	switch format {
+	case dto.ExportFormatCSV:
+		return newCSVLicenseRowWriter(output, columns)
+	case dto.ExportFormatNDJSON:
+		return &ndjsonLicenseRowWriter{output: bufio.NewWriter(output), columns: columns}, nil
+	case dto.ExportFormatParquet:
+		return newParquetLicenseRowWriter(output, columns), nil
+	}
+	return nil, errorInfo.NewAppError(errorInfo.ErrInvalidData, "LicenseExporterUseCase", "newLicenseRowWriter", "format must be csv, ndjson or parquet")
+}
+
+type csvLicenseRowWriter struct {
+	writer  *csv.Writer
+	columns []exportColumn
+	record  []string
+}
+
+func newCSVLicenseRowWriter(output io.Writer, columns []exportColumn) (*csvLicenseRowWriter, error) {
+	writer := csv.NewWriter(output)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	return &csvLicenseRowWriter{writer: writer, columns: columns, record: make([]string, len(columns))}, nil
+}
+
+func (w *csvLicenseRowWriter) Write(values []any) error {
+	for i, value := range values {
+		w.record[i] = formatExportValue(value, w.columns[i].kind)
+	}
+	return w.writer.Write(w.record)
+}
+
+func (w *csvLicenseRowWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// ndjsonLicenseRowWriter arma cada objeto a mano para respetar el orden de las
+// columnas elegidas.
+type ndjsonLicenseRowWriter struct {
+	output  *bufio.Writer
+	columns []exportColumn
+	line    bytes.Buffer
+}
+
+func (w *ndjsonLicenseRowWriter) Write(values []any) error {
+	w.line.Reset()
+	w.line.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			w.line.WriteByte(',')
+		}
+		name, _ := json.Marshal(w.columns[i].name)
+		w.line.Write(name)
+		w.line.WriteByte(':')
+
+		var encoded []byte
+		switch value.(type) {
+		case nil:
+			encoded = []byte("null")
+		case string, time.Time:
+			encoded, _ = json.Marshal(formatExportValue(value, w.columns[i].kind))
+		default:
+			encoded, _ = json.Marshal(value)
+		}
+		w.line.Write(encoded)
+	}
+	w.line.WriteString("}\n")
+
+	_, err := w.output.Write(w.line.Bytes())
+	return err
+}
+
+func (w *ndjsonLicenseRowWriter) Close() error {
+	return w.output.Flush()
+}
+
+type parquetLicenseRowWriter struct {
+	writer  *parquet.Writer
+	columns []exportColumn
+	// indexes[i] es la columna hoja de columns[i]; parquet ordena las columnas
+	// de un Group por nombre.
+	indexes []int
+	row     parquet.Row
+}
+
+func newParquetLicenseRowWriter(output io.Writer, columns []exportColumn) *parquetLicenseRowWriter {
+	group := parquet.Group{}
+	for _, column := range columns {
+		group[column.name] = parquet.Optional(column.parquetNode())
+	}
+	schema := parquet.NewSchema("license", group)
+
+	leaves := make(map[string]int, len(columns))
+	for i, path := range schema.Columns() {
+		leaves[path[0]] = i
+	}
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		indexes[i] = leaves[column.name]
+	}
+
+	return &parquetLicenseRowWriter{
+		writer:  parquet.NewWriter(output, schema, parquet.MaxRowsPerRowGroup(parquetRowGroupSize), parquet.Compression(&parquet.Snappy)),
+		columns: columns,
+		indexes: indexes,
+		row:     make(parquet.Row, len(columns)),
+	}
+}
+
+func (w *parquetLicenseRowWriter) Write(values []any) error {
+	for i, value := range values {
+		index := w.indexes[i]
+		var cell parquet.Value
+		switch v := value.(type) {
+		case nil:
+			w.row[index] = parquet.NullValue().Level(0, 0, index)
+			continue
+		case string:
+			cell = parquet.ValueOf(v)
+		case int:
+			cell = parquet.ValueOf(int32(v))
+		case float64:
+			cell = parquet.ValueOf(v)
+		case time.Time:
+			if w.columns[i].kind == exportDate {
+				cell = parquet.ValueOf(int32(utcDate(v).Unix() / 86400))
+			} else {
+				cell = parquet.ValueOf(v.UnixMilli())
+			}
+		}
+		w.row[index] = cell.Level(0, 1, index)
+	}
+	_, err := w.writer.WriteRows([]parquet.Row{w.row})
+	return err
+}
+
+func (w *parquetLicenseRowWriter) Close() error {
+	return w.writer.Close()
+}
+
+func (column exportColumn) parquetNode() parquet.Node {
+	switch column.kind {
+	case exportInt:
+		return parquet.Int(32)
+	case exportFloat:
+		return parquet.Leaf(parquet.DoubleType)
+	case exportDate:
+		return parquet.Date()
+	case exportTimestamp:
+		return parquet.Timestamp(parquet.Millisecond)
+	default:
+		return parquet.String()
+	}
+}
+
+// formatExportValue da la forma de texto de un valor: fechas YYYY-MM-DD,
+// instantes RFC 3339 en UTC y nil como cadena vacía.
+func formatExportValue(value any, kind exportColumnKind) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		if kind == exportDate {
+			return v.Format(dto.DateFormat)
+		}
+		return v.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
+
+func utcDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
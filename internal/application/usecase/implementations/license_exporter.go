@@ -0,0 +1,153 @@
+package implementations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/usecase/contrats"
+	model "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	valueobject "license-service/internal/domain/valueobject"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"strings"
+	"time"
+)
+
+// ExportSettings agrupa la configuración de las exportaciones masivas.
+type ExportSettings struct {
+	// PageSize es la cantidad de licencias que se leen por consulta.
+	PageSize int
+	// PseudonymKey firma los seudónimos de diagnóstico; sin clave no se puede
+	// pedir la seudonimización.
+	PseudonymKey string
+	// JobTTL es cuánto se conserva una exportación asíncrona terminada.
+	JobTTL time.Duration
+	// MaxRunningJobs limita las exportaciones asíncronas simultáneas; las demás
+	// esperan su turno como pendientes.
+	MaxRunningJobs int
+}
+
+// LicenseExporterUseCase escribe las licencias que cumplen el filtro a medida
+// que las lee por páginas, sin cargarlas todas en memoria.
+type LicenseExporterUseCase struct {
+	licenseRepository repositories.LicenseRepository
+	settings          ExportSettings
+}
+
+func NewLicenseExporterUseCase(licenseRepository repositories.LicenseRepository, settings ExportSettings) contrats.LicenseExporter {
+	return newLicenseExporter(licenseRepository, settings)
+}
+
+func newLicenseExporter(licenseRepository repositories.LicenseRepository, settings ExportSettings) *LicenseExporterUseCase {
+	return &LicenseExporterUseCase{
+		licenseRepository: licenseRepository,
+		settings:          settings,
+	}
+}
+
+// exportPlan es un pedido de exportación ya validado.
+type exportPlan struct {
+	format        string
+	criteria      repositories.LicenseCriteria
+	columns       []exportColumn
+	pseudonymizer *pseudonymizer
+}
+
+func (usecase *LicenseExporterUseCase) Execute(ctx context.Context, request dto.ExportRequestDTO, output io.Writer) (_ int, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicenseExporterUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicenseExporterUseCase", err)
+	}()
+
+	plan, err := usecase.plan(request)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseExporterUseCase", "Execute", err, "export request rejected")
+		return 0, err
+	}
+	return usecase.export(ctx, plan, output)
+}
+
+// plan valida el pedido antes de escribir nada, así un error de parámetros
+// todavía puede responderse como 400.
+func (usecase *LicenseExporterUseCase) plan(request dto.ExportRequestDTO) (*exportPlan, error) {
+	format := strings.ToLower(strings.TrimSpace(request.Format))
+	if format == "" {
+		format = dto.ExportFormatCSV
+	}
+	if format != dto.ExportFormatCSV && format != dto.ExportFormatNDJSON && format != dto.ExportFormatParquet {
+		return nil, errorInfo.NewAppError(errorInfo.ErrInvalidData, "LicenseExporterUseCase", "plan", "format must be csv, ndjson or parquet")
+	}
+
+	if request.Filter.Type != "" {
+		if _, err := valueobject.NewLicenseType(request.Filter.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	columns, err := selectExportColumns(request.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &exportPlan{
+		format: format,
+		criteria: repositories.LicenseCriteria{
+			PatientID: request.Filter.PatientID,
+			Type:      request.Filter.Type,
+		},
+		columns: columns,
+	}
+	if request.PseudonymizeDiagnosis {
+		if usecase.settings.PseudonymKey == "" {
+			return nil, errorInfo.NewAppError(errorInfo.ErrInvalidData, "LicenseExporterUseCase", "plan", "diagnosis pseudonymization is not configured")
+		}
+		plan.pseudonymizer = &pseudonymizer{key: []byte(usecase.settings.PseudonymKey)}
+	}
+	return plan, nil
+}
+
+func (usecase *LicenseExporterUseCase) export(ctx context.Context, plan *exportPlan, output io.Writer) (int, error) {
+	logger.FromContext(ctx).Info("LicenseExporterUseCase", "export", fmt.Sprintf("exporting licenses as %s, %d columns", plan.format, len(plan.columns)))
+
+	writer, err := newLicenseRowWriter(plan.format, output, plan.columns)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseExporterUseCase", "export", err, "failed to start export")
+		return 0, err
+	}
+
+	rows := 0
+	values := make([]any, len(plan.columns))
+	err = usecase.licenseRepository.ScanByCriteria(ctx, plan.criteria, usecase.settings.PageSize, func(licenses []*model.License) error {
+		for _, license := range licenses {
+			for i, column := range plan.columns {
+				values[i] = column.value(license)
+				if column.name == "diagnosis" && plan.pseudonymizer != nil {
+					values[i] = plan.pseudonymizer.pseudonym(license.Diagnosis)
+				}
+			}
+			if err := writer.Write(values); err != nil {
+				return errorInfo.WrapError(errorInfo.ErrInternalError, "LicenseExporterUseCase", "export", "export output could not be written", err)
+			}
+			rows++
+		}
+		return nil
+	})
+	if err == nil {
+		if err = writer.Close(); err != nil {
+			err = errorInfo.WrapError(errorInfo.ErrInternalError, "LicenseExporterUseCase", "export", "export output could not be written", err)
+		}
+	}
+	metrics.LicensesExportedTotal.WithLabelValues(plan.format).Add(float64(rows))
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseExporterUseCase", "export", err, fmt.Sprintf("export stopped after %d licenses", rows))
+		return rows, err
+	}
+
+	logger.FromContext(ctx).Info("LicenseExporterUseCase", "export", fmt.Sprintf("exported %d licenses", rows))
+	return rows, nil
+}
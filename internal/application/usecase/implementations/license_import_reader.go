@@ -0,0 +1,186 @@
+package implementations
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"license-service/internal/application/dto"
+	errorInfo "license-service/pkg/log/error"
+	"strconv"
+	"strings"
+)
+
+// maxImportLineBytes acota una línea NDJSON; una licencia ocupa menos de 1 KB.
+const maxImportLineBytes = 1 << 20
+
+// licenseRowReader lee las filas de una importación de a una, sin cargar el
+// archivo completo. Next devuelve io.EOF al terminar; rowErr invalida solo esa
+// fila, mientras que err detiene la importación.
+type licenseRowReader interface {
+	Next() (row dto.ImportLicenseRowDTO, rowErr error, err error)
+}
+
+func newLicenseRowReader(format string, input io.Reader) (licenseRowReader, error) {
+	switch strings.ToLower(format) {
+	case dto.ImportFormatCSV:
+		return newCSVLicenseRowReader(input)
+	case dto.ImportFormatNDJSON:
+		scanner := bufio.NewScanner(input)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+		return &ndjsonLicenseRowReader{scanner: scanner}, nil
+	}
+	return nil, errorInfo.NewAppError(errorInfo.ErrInvalidData, "LicenseImportUseCase", "newLicenseRowReader", "format must be csv or ndjson")
+}
+
+// ndjsonLicenseRowReader lee un objeto JSON por línea, con los mismos nombres
+// de campo que POST /licenses. Las líneas en blanco se ignoran.
+type ndjsonLicenseRowReader struct {
+	scanner *bufio.Scanner
+}
+
+func (r *ndjsonLicenseRowReader) Next() (dto.ImportLicenseRowDTO, error, error) {
+	for r.scanner.Scan() {
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var row dto.ImportLicenseRowDTO
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			return row, rowFormatError("invalid JSON line: " + err.Error()), nil
+		}
+		return row, nil, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return dto.ImportLicenseRowDTO{}, nil, errorInfo.WrapError(errorInfo.ErrInvalidData, "LicenseImportUseCase", "readNDJSON", "import file could not be read", err)
+	}
+	return dto.ImportLicenseRowDTO{}, nil, io.EOF
+}
+
+// csvLicenseRowReader lee un CSV cuyo encabezado usa los nombres de campo de
+// POST /licenses, en cualquier orden. employerRuts separa los RUT con ";".
+type csvLicenseRowReader struct {
+	reader  *csv.Reader
+	columns []csvColumn
+}
+
+type csvColumn func(row *dto.ImportLicenseRowDTO, value string) error
+
+func newCSVLicenseRowReader(input io.Reader) (*csvLicenseRowReader, error) {
+	reader := csv.NewReader(input)
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errorInfo.NewAppError(errorInfo.ErrMissingRequiredField, "LicenseImportUseCase", "readCSV", "CSV header is required")
+	}
+	if err != nil {
+		return nil, errorInfo.WrapError(errorInfo.ErrInvalidData, "LicenseImportUseCase", "readCSV", "invalid CSV header", err)
+	}
+
+	columns := make([]csvColumn, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		column, ok := csvColumns[name]
+		if !ok {
+			return nil, errorInfo.NewAppError(errorInfo.ErrInvalidData, "LicenseImportUseCase", "readCSV", "unknown CSV column: "+name)
+		}
+		if seen[name] {
+			return nil, errorInfo.NewAppError(errorInfo.ErrInvalidData, "LicenseImportUseCase", "readCSV", "repeated CSV column: "+name)
+		}
+		seen[name] = true
+		columns[i] = column
+	}
+	reader.FieldsPerRecord = len(header)
+
+	return &csvLicenseRowReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvLicenseRowReader) Next() (dto.ImportLicenseRowDTO, error, error) {
+	var row dto.ImportLicenseRowDTO
+
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return row, nil, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return row, rowFormatError("invalid CSV row: " + parseErr.Err.Error()), nil
+	}
+	if err != nil {
+		return row, nil, errorInfo.WrapError(errorInfo.ErrInvalidData, "LicenseImportUseCase", "readCSV", "import file could not be read", err)
+	}
+
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if err := r.columns[i](&row, value); err != nil {
+			return row, err, nil
+		}
+	}
+	return row, nil, nil
+}
+
+var csvColumns = map[string]csvColumn{
+	"folio":        func(row *dto.ImportLicenseRowDTO, value string) error { row.Folio = value; return nil },
+	"patientId":    func(row *dto.ImportLicenseRowDTO, value string) error { row.PatientID = value; return nil },
+	"doctorId":     func(row *dto.ImportLicenseRowDTO, value string) error { row.DoctorID = value; return nil },
+	"diagnosis":    func(row *dto.ImportLicenseRowDTO, value string) error { row.Diagnosis = value; return nil },
+	"type":         func(row *dto.ImportLicenseRowDTO, value string) error { row.Type = value; return nil },
+	"childRut":     func(row *dto.ImportLicenseRowDTO, value string) error { row.ChildRut = value; return nil },
+	"restType":     func(row *dto.ImportLicenseRowDTO, value string) error { row.RestType = value; return nil },
+	"restLocation": func(row *dto.ImportLicenseRowDTO, value string) error { row.RestLocation = value; return nil },
+	"restAddress":  func(row *dto.ImportLicenseRowDTO, value string) error { row.RestAddress = value; return nil },
+	"insurerRut":   func(row *dto.ImportLicenseRowDTO, value string) error { row.InsurerRut = value; return nil },
+	"employerRuts": func(row *dto.ImportLicenseRowDTO, value string) error {
+		for _, rut := range strings.Split(value, ";") {
+			if rut = strings.TrimSpace(rut); rut != "" {
+				row.EmployerRuts = append(row.EmployerRuts, rut)
+			}
+		}
+		return nil
+	},
+	"days": func(row *dto.ImportLicenseRowDTO, value string) error {
+		days, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			return rowFormatError("days must be a number between 1 and 255")
+		}
+		row.Days = uint8(days)
+		return nil
+	},
+	"startDate": func(row *dto.ImportLicenseRowDTO, value string) error {
+		date, err := parseImportDate("startDate", value)
+		row.StartDate = date
+		return err
+	},
+	"dueDate": func(row *dto.ImportLicenseRowDTO, value string) error {
+		date, err := parseImportDate("dueDate", value)
+		row.DueDate = &date
+		return err
+	},
+	"issuedAt": func(row *dto.ImportLicenseRowDTO, value string) error {
+		date, err := parseImportDate("issuedAt", value)
+		row.IssuedAt = &date
+		return err
+	},
+}
+
+func parseImportDate(column, value string) (dto.CustomDate, error) {
+	date, err := dto.ParseCustomDate(value)
+	if err != nil {
+		return date, rowFormatError(fmt.Sprintf("%s must be a date (%s)", column, dto.DateFormat))
+	}
+	return date, nil
+}
+
+func rowFormatError(message string) error {
+	return errorInfo.NewAppError(errorInfo.ErrInvalidData, "LicenseImportUseCase", "readRow", message)
+}
@@ -0,0 +1,243 @@
+package implementations
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	model "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"strings"
+	"time"
+)
+
+// LicenseImportUseCase carga licencias históricas desde un CSV o NDJSON. Cada
+// fila se valida igual que POST /licenses y las válidas se guardan por tramos,
+// cada uno en su propia transacción: un tramo que falla no deshace los
+// anteriores y sus filas se informan con el error.
+type LicenseImportUseCase struct {
+	licenseRepository repositories.LicenseRepository
+	chunkSize         int
+	now               func() time.Time
+}
+
+func NewLicenseImportUseCase(licenseRepository repositories.LicenseRepository, chunkSize int) contrats.LicenseImporter {
+	return &LicenseImportUseCase{
+		licenseRepository: licenseRepository,
+		chunkSize:         chunkSize,
+		now:               time.Now,
+	}
+}
+
+// importEntry es una fila válida que espera su tramo; index apunta a su
+// resultado en el informe.
+type importEntry struct {
+	index     int
+	license   *model.License
+	generated bool
+}
+
+func (usecase *LicenseImportUseCase) Execute(ctx context.Context, input io.Reader, options dto.ImportOptionsDTO) (_ *dto.ImportReportDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicenseImportUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicenseImportUseCase", err)
+	}()
+
+	chunkSize := options.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = usecase.chunkSize
+	}
+	if chunkSize < 1 {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			"LicenseImportUseCase",
+			"Execute",
+			"chunk size must be greater than 0",
+		)
+		logger.FromContext(ctx).Error("LicenseImportUseCase", "Execute", appErr, "invalid chunk size")
+		return nil, appErr
+	}
+
+	reader, err := newLicenseRowReader(options.Format, input)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseImportUseCase", "Execute", err, "import file rejected")
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("LicenseImportUseCase", "Execute", fmt.Sprintf("importing %s file, dry run: %t, chunk size: %d", options.Format, options.DryRun, chunkSize))
+
+	report := &dto.ImportReportDTO{DryRun: options.DryRun, Rows: []dto.ImportRowResultDTO{}}
+	now := usecase.now()
+	seenFolios := make(map[string]int)
+	chunk := make([]importEntry, 0, chunkSize)
+
+	for {
+		row, rowErr, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return usecase.interrupted(ctx, report, chunk, errorInfo.ErrInvalidData, err)
+		}
+		if ctx.Err() != nil {
+			return usecase.interrupted(ctx, report, chunk, errorInfo.ErrInternalError, ctx.Err())
+		}
+
+		report.Total++
+		index := len(report.Rows)
+		report.Rows = append(report.Rows, dto.ImportRowResultDTO{Row: report.Total})
+
+		if rowErr == nil {
+			var entry importEntry
+			entry, rowErr = usecase.buildEntry(row, now, seenFolios, report.Total)
+			entry.index = index
+			if rowErr == nil {
+				chunk = append(chunk, entry)
+			}
+		}
+		if rowErr != nil {
+			usecase.fail(report, index, rowErr)
+		}
+
+		if len(chunk) == chunkSize {
+			usecase.commit(ctx, report, chunk)
+			chunk = chunk[:0]
+		}
+	}
+	usecase.commit(ctx, report, chunk)
+
+	logger.FromContext(ctx).Info("LicenseImportUseCase", "Execute", fmt.Sprintf("import finished: %d rows, %d created, %d valid, %d failed", report.Total, report.Created, report.Valid, report.Failed))
+	return report, nil
+}
+
+// buildEntry valida una fila y le asigna su folio. Un folio en papel solo puede
+// aparecer una vez en el archivo; los que ya existen en la base se detectan al
+// guardar el tramo.
+func (usecase *LicenseImportUseCase) buildEntry(row dto.ImportLicenseRowDTO, now time.Time, seenFolios map[string]int, rowNumber int) (importEntry, error) {
+	issuedAt := now
+	if row.IssuedAt != nil {
+		if row.IssuedAt.Time.After(now) {
+			return importEntry{}, errorInfo.NewAppError(errorInfo.ErrInvalidData, "LicenseImportUseCase", "buildEntry", "issuedAt cannot be in the future")
+		}
+		issuedAt = row.IssuedAt.Time
+	}
+
+	license, err := buildLicense(row.CreateLicenseDTO, issuedAt)
+	if err != nil {
+		return importEntry{}, err
+	}
+
+	folio := strings.TrimSpace(row.Folio)
+	if folio == "" {
+		license.GenerateFolio()
+		return importEntry{license: license, generated: true}, nil
+	}
+	if previous, ok := seenFolios[folio]; ok {
+		return importEntry{}, errorInfo.NewAppError(errorInfo.ErrConflict, "LicenseImportUseCase", "buildEntry", fmt.Sprintf("folio %s is repeated, first seen in row %d", folio, previous))
+	}
+	seenFolios[folio] = rowNumber
+	license.Folio = folio
+	return importEntry{license: license}, nil
+}
+
+// commit guarda un tramo en una transacción, descartando antes los folios que
+// ya existen. En un dry run solo hace esa consulta y marca las filas válidas.
+func (usecase *LicenseImportUseCase) commit(ctx context.Context, report *dto.ImportReportDTO, chunk []importEntry) {
+	if len(chunk) == 0 {
+		return
+	}
+
+	folios := make([]string, 0, len(chunk))
+	for _, entry := range chunk {
+		folios = append(folios, entry.license.Folio)
+	}
+	existing, err := usecase.licenseRepository.FindByFolios(ctx, folios)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseImportUseCase", "commit", err, "failed to check existing folios")
+		for _, entry := range chunk {
+			usecase.fail(report, entry.index, err)
+		}
+		return
+	}
+	existingFolios := make(map[string]bool, len(existing))
+	for _, license := range existing {
+		existingFolios[license.Folio] = true
+	}
+
+	pending := make([]importEntry, 0, len(chunk))
+	for _, entry := range chunk {
+		if existingFolios[entry.license.Folio] {
+			usecase.fail(report, entry.index, errorInfo.NewAppError(errorInfo.ErrConflict, "LicenseImportUseCase", "commit", "license with this folio already exists"))
+			continue
+		}
+		pending = append(pending, entry)
+	}
+
+	if report.DryRun {
+		for _, entry := range pending {
+			result := &report.Rows[entry.index]
+			result.Status = dto.ImportRowValid
+			if !entry.generated {
+				result.Folio = entry.license.Folio
+			}
+			report.Valid++
+		}
+		return
+	}
+
+	licenses := make([]*model.License, 0, len(pending))
+	for _, entry := range pending {
+		licenses = append(licenses, entry.license)
+	}
+	if err := usecase.licenseRepository.SaveBatch(ctx, licenses); err != nil {
+		logger.FromContext(ctx).Error("LicenseImportUseCase", "commit", err, fmt.Sprintf("chunk of %d licenses was not saved", len(licenses)))
+		for _, entry := range pending {
+			usecase.fail(report, entry.index, err)
+		}
+		return
+	}
+
+	for _, entry := range pending {
+		result := &report.Rows[entry.index]
+		result.Status = dto.ImportRowCreated
+		result.Folio = entry.license.Folio
+		report.Created++
+	}
+	metrics.LicensesImportedTotal.Add(float64(len(pending)))
+}
+
+// interrupted devuelve el informe hasta la última fila leída junto con el
+// error. Los tramos confirmados no se deshacen; las filas del tramo pendiente
+// no se guardan y quedan como no procesadas.
+func (usecase *LicenseImportUseCase) interrupted(ctx context.Context, report *dto.ImportReportDTO, chunk []importEntry, code errorInfo.ErrorCode, err error) (*dto.ImportReportDTO, error) {
+	for _, entry := range chunk {
+		report.Rows[entry.index].Status = dto.ImportRowNotProcessed
+		report.NotProcessed++
+	}
+
+	appErr := errorInfo.WrapError(
+		code,
+		"LicenseImportUseCase",
+		"Execute",
+		fmt.Sprintf("import stopped after %d rows, %d licenses were already created and %d were not processed", report.Total, report.Created, report.NotProcessed),
+		err,
+	)
+	logger.FromContext(ctx).Error("LicenseImportUseCase", "Execute", appErr, "import interrupted")
+
+	report.Interrupted = true
+	report.Error = mapper.ToItemErrorDTO(appErr)
+	return report, appErr
+}
+
+func (usecase *LicenseImportUseCase) fail(report *dto.ImportReportDTO, index int, err error) {
+	report.Rows[index].Status = dto.ImportRowFailed
+	report.Rows[index].Error = mapper.ToItemErrorDTO(err)
+	report.Failed++
+}
@@ -0,0 +1,133 @@
+package implementations
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"license-service/internal/application/dto"
+	model "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	errorInfo "license-service/pkg/log/error"
+)
+
+// importStore guarda los lotes en memoria; ningún folio existe de antemano.
+type importStore struct {
+	repositories.LicenseRepository
+	saved []string
+}
+
+func (s *importStore) FindByFolios(context.Context, []string) ([]*model.License, error) {
+	return nil, nil
+}
+
+func (s *importStore) SaveBatch(_ context.Context, licenses []*model.License) error {
+	for _, license := range licenses {
+		s.saved = append(s.saved, license.Folio)
+	}
+	return nil
+}
+
+func importLine(folio string) string {
+	return fmt.Sprintf(`{"folio":%q,"patientId":"12345678-9","doctorId":"DOC001","diagnosis":"GRIPE_COMUN","startDate":"2024-03-04","days":5,"employerRuts":["76086428-5"],"issuedAt":"2024-03-04"}`+"\n", folio)
+}
+
+func newTestImporter(store *importStore) *LicenseImportUseCase {
+	usecase := NewLicenseImportUseCase(store, 2).(*LicenseImportUseCase)
+	usecase.now = func() time.Time { return time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC) }
+	return usecase
+}
+
+func TestImportCompletes(t *testing.T) {
+	store := &importStore{}
+	input := importLine("P-1") + importLine("P-2") + importLine("P-3")
+
+	report, err := newTestImporter(store).Execute(context.Background(), strings.NewReader(input), dto.ImportOptionsDTO{Format: dto.ImportFormatNDJSON})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Interrupted || report.Created != 3 || report.Failed != 0 || len(store.saved) != 3 {
+		t.Fatalf("report = %+v, saved = %v", report, store.saved)
+	}
+}
+
+// Si la lectura se corta, el informe llega con el error: el tramo confirmado
+// figura como creado y el pendiente como no procesado.
+func TestImportInterruptedReturnsPartialReport(t *testing.T) {
+	readErr := errors.New("connection reset")
+	tests := []struct {
+		name  string
+		input io.Reader
+		cause error
+	}{
+		{
+			name:  "read error",
+			input: io.MultiReader(strings.NewReader(importLine("P-1")+importLine("P-2")+importLine("P-3")), iotest.ErrReader(readErr)),
+			cause: readErr,
+		},
+		{
+			name:  "line too long",
+			input: strings.NewReader(importLine("P-1") + importLine("P-2") + importLine("P-3") + strings.Repeat("x", maxImportLineBytes+1) + "\n"),
+			cause: bufio.ErrTooLong,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &importStore{}
+			report, err := newTestImporter(store).Execute(context.Background(), tt.input, dto.ImportOptionsDTO{Format: dto.ImportFormatNDJSON})
+
+			if !errors.Is(err, tt.cause) {
+				t.Fatalf("err = %v, want %v", err, tt.cause)
+			}
+			if report == nil {
+				t.Fatal("partial report discarded")
+			}
+			if !report.Interrupted || report.Error == nil || report.Error.Code != string(errorInfo.ErrInvalidData) {
+				t.Errorf("report = %+v, want it marked interrupted with the error", report)
+			}
+			if report.Total != 3 || report.Created != 2 || report.NotProcessed != 1 {
+				t.Errorf("totals = %+v, want 3 rows, 2 created, 1 not processed", report)
+			}
+			wantStatus := []string{dto.ImportRowCreated, dto.ImportRowCreated, dto.ImportRowNotProcessed}
+			for i, row := range report.Rows {
+				if row.Status != wantStatus[i] {
+					t.Errorf("row %d status = %q, want %q", row.Row, row.Status, wantStatus[i])
+				}
+			}
+			if len(store.saved) != 2 {
+				t.Errorf("saved = %v, want only the committed chunk", store.saved)
+			}
+		})
+	}
+}
+
+func TestImportCanceledReturnsPartialReport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	store := &importStore{}
+	// La tercera fila se lee después de cancelar.
+	input := io.MultiReader(
+		strings.NewReader(importLine("P-1")+importLine("P-2")),
+		readerFunc(func(p []byte) (int, error) {
+			cancel()
+			return copy(p, importLine("P-3")), io.EOF
+		}),
+	)
+
+	report, err := newTestImporter(store).Execute(ctx, input, dto.ImportOptionsDTO{Format: dto.ImportFormatNDJSON})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if report == nil || !report.Interrupted || report.Created != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+type readerFunc func([]byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) { return f(p) }
@@ -0,0 +1,124 @@
+package implementations
+
+import (
+	"context"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	model "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	"license-service/pkg/auth"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"time"
+)
+
+// LicenseResolverUseCase registra la resolución de la aseguradora (aprobada,
+// reducida o rechazada) sobre una licencia en revisión.
+type LicenseResolverUseCase struct {
+	licenseRepository repositories.LicenseRepository
+	now               func() time.Time
+}
+
+func NewLicenseResolverUseCase(licenseRepository repositories.LicenseRepository) contrats.LicenseResolver {
+	return &LicenseResolverUseCase{
+		licenseRepository: licenseRepository,
+		now:               time.Now,
+	}
+}
+
+func (usecase *LicenseResolverUseCase) Execute(ctx context.Context, folio string, resolution dto.ResolutionDTO, expectedRevision int) (_ *dto.LicenseDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicenseResolverUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicenseResolverUseCase", err)
+	}()
+
+	logger.FromContext(ctx).Info("LicenseResolverUseCase", "Execute", "resolving folio: "+folio+" as "+resolution.Status)
+
+	if folio == "" {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrMissingRequiredField,
+			"LicenseResolverUseCase",
+			"Execute",
+			"folio is required",
+		)
+		logger.FromContext(ctx).Error("LicenseResolverUseCase", "Execute", appErr, "empty folio provided")
+		return nil, appErr
+	}
+
+	license, err := usecase.licenseRepository.FindByFolio(ctx, folio)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseResolverUseCase", "Execute", err, "failed to retrieve license from repository")
+		return nil, err
+	}
+
+	if license == nil {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrNotFound,
+			"LicenseResolverUseCase",
+			"Execute",
+			"license not found",
+		)
+		logger.FromContext(ctx).Info("LicenseResolverUseCase", "Execute", "license not found for folio: "+folio)
+		return nil, appErr
+	}
+
+	if err := license.CheckRevision(expectedRevision); err != nil {
+		logger.FromContext(ctx).Error("LicenseResolverUseCase", "Execute", err, "stale revision for folio: "+folio)
+		return nil, err
+	}
+
+	resolvedBy, err := usecase.resolveInsurer(ctx, license)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseResolverUseCase", "Execute", err, "insurer is not assigned to this license")
+		return nil, err
+	}
+
+	if err := license.Resolve(model.Resolution{
+		Status:       resolution.Status,
+		Reason:       resolution.Reason,
+		ApprovedDays: resolution.ApprovedDays,
+		ResolvedBy:   resolvedBy,
+	}, usecase.now()); err != nil {
+		logger.FromContext(ctx).Error("LicenseResolverUseCase", "Execute", err, "resolution rejected for folio: "+folio)
+		return nil, err
+	}
+
+	if err := usecase.licenseRepository.UpdateAdjudication(ctx, license); err != nil {
+		logger.FromContext(ctx).Error("LicenseResolverUseCase", "Execute", err, "failed to save resolution")
+		return nil, err
+	}
+	metrics.LicensesAdjudicatedTotal.WithLabelValues(license.Adjudication.Status).Inc()
+
+	logger.FromContext(ctx).Info("LicenseResolverUseCase", "Execute", "license "+folio+" resolved as "+license.Adjudication.Status)
+	return mapper.ToLicenseDTO(license), nil
+}
+
+// resolveInsurer comprueba que la aseguradora autenticada sea la asignada a la
+// licencia; si aún no tiene una, queda asignada a quien la resuelve. Devuelve
+// el sujeto que firma la resolución.
+func (usecase *LicenseResolverUseCase) resolveInsurer(ctx context.Context, license *model.License) (string, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	if !principal.HasRole(auth.RoleInsurer) {
+		return principal.Subject, nil
+	}
+
+	switch {
+	case license.InsurerRut == "" && principal.Rut != "":
+		license.InsurerRut = principal.Rut
+	case license.InsurerRut != "" && license.InsurerRut != principal.Rut:
+		return "", errorInfo.NewAppError(
+			errorInfo.ErrForbidden,
+			"LicenseResolverUseCase",
+			"resolveInsurer",
+			"license is assigned to another insurer",
+		)
+	}
+	return principal.Subject, nil
+}
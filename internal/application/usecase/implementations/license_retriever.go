@@ -3,26 +3,33 @@ package implementations
 import (
 	"context"
 	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
 	"license-service/internal/application/usecase/contrats"
 	"license-service/internal/domain/repositories"
 	errorInfo "license-service/pkg/log/error"
 	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
 )
 
 type LicenseRetrieverUseCase struct {
 	licenseRepository repositories.LicenseRepository
-	logger            logger.Logger
 }
 
 func NewLicenseRetrieverUseCase(licenseRepository repositories.LicenseRepository) contrats.LicenseRetriever {
 	return &LicenseRetrieverUseCase{
 		licenseRepository: licenseRepository,
-		logger:            *logger.NewLogger(),
 	}
 }
 
-func (usecase *LicenseRetrieverUseCase) Execute(ctx context.Context, folio string) (*dto.LicenseDTO, error) {
-	usecase.logger.Info("LicenseRetrieverUseCase", "Execute", "retrieving license with folio: "+folio)
+func (usecase *LicenseRetrieverUseCase) Execute(ctx context.Context, folio string) (_ *dto.LicenseDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicenseRetrieverUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicenseRetrieverUseCase", err)
+	}()
+
+	logger.FromContext(ctx).Info("LicenseRetrieverUseCase", "Execute", "retrieving license with folio: "+folio)
 
 	if folio == "" {
 		appErr := errorInfo.NewAppError(
@@ -31,13 +38,13 @@ func (usecase *LicenseRetrieverUseCase) Execute(ctx context.Context, folio strin
 			"Execute",
 			"folio is required",
 		)
-		usecase.logger.Error("LicenseRetrieverUseCase", "Execute", appErr, "empty folio provided")
+		logger.FromContext(ctx).Error("LicenseRetrieverUseCase", "Execute", appErr, "empty folio provided")
 		return nil, appErr
 	}
 
 	license, err := usecase.licenseRepository.FindByFolio(ctx, folio)
 	if err != nil {
-		usecase.logger.Error("LicenseRetrieverUseCase", "Execute", err, "failed to retrieve license from repository")
+		logger.FromContext(ctx).Error("LicenseRetrieverUseCase", "Execute", err, "failed to retrieve license from repository")
 		return nil, err
 	}
 
@@ -48,20 +55,12 @@ func (usecase *LicenseRetrieverUseCase) Execute(ctx context.Context, folio strin
 			"Execute",
 			"license not found",
 		)
-		usecase.logger.Info("LicenseRetrieverUseCase", "Execute", "license not found for folio: "+folio)
+		logger.FromContext(ctx).Info("LicenseRetrieverUseCase", "Execute", "license not found for folio: "+folio)
 		return nil, appErr
 	}
 
-	responseDTO := &dto.LicenseDTO{
-		Folio:     license.Folio,
-		PatientID: license.PatientID,
-		DoctorID:  license.DoctorID,
-		Diagnosis: license.Diagnosis,
-		StartDate: license.StartDate.Format("2006-01-02"),
-		Days:      license.Days,
-		Status:    license.Status,
-	}
+	responseDTO := mapper.ToLicenseDTO(license)
 
-	usecase.logger.Info("LicenseRetrieverUseCase", "Execute", "license retrieved successfully for folio: "+folio)
+	logger.FromContext(ctx).Info("LicenseRetrieverUseCase", "Execute", "license retrieved successfully for folio: "+folio)
 	return responseDTO, nil
 }
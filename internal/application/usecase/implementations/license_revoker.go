@@ -0,0 +1,60 @@
+package implementations
+
+import (
+	"context"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	"license-service/internal/domain/repositories"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+)
+
+type LicenseRevokerUseCase struct {
+	licenseRepository repositories.LicenseRepository
+}
+
+func NewLicenseRevokerUseCase(licenseRepository repositories.LicenseRepository) contrats.LicenseRevoker {
+	return &LicenseRevokerUseCase{
+		licenseRepository: licenseRepository,
+	}
+}
+
+func (usecase *LicenseRevokerUseCase) Execute(ctx context.Context, folio string) (_ *dto.LicenseDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicenseRevokerUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicenseRevokerUseCase", err)
+	}()
+
+	logger.FromContext(ctx).Info("LicenseRevokerUseCase", "Execute", "revoking license with folio: "+folio)
+
+	if folio == "" {
+		return nil, errorInfo.NewAppError(errorInfo.ErrMissingRequiredField, "LicenseRevokerUseCase", "Execute", "folio is required")
+	}
+
+	license, err := usecase.licenseRepository.FindByFolio(ctx, folio)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseRevokerUseCase", "Execute", err, "failed to retrieve license from repository")
+		return nil, err
+	}
+	if license == nil {
+		return nil, errorInfo.NewAppError(errorInfo.ErrNotFound, "LicenseRevokerUseCase", "Execute", "license not found")
+	}
+
+	if err = license.Revoke(); err != nil {
+		logger.FromContext(ctx).Error("LicenseRevokerUseCase", "Execute", err, "license cannot be revoked")
+		return nil, err
+	}
+
+	if err = usecase.licenseRepository.UpdateStatus(ctx, license); err != nil {
+		logger.FromContext(ctx).Error("LicenseRevokerUseCase", "Execute", err, "failed to persist revocation")
+		return nil, err
+	}
+
+	metrics.LicensesRevokedTotal.Inc()
+	logger.FromContext(ctx).Info("LicenseRevokerUseCase", "Execute", "license revoked: "+folio)
+	return mapper.ToLicenseDTO(license), nil
+}
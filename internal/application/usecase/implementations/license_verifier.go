@@ -2,77 +2,94 @@ package implementations
 
 import (
 	"context"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
 	"license-service/internal/application/usecase/contrats"
+	model "license-service/internal/domain/model"
 	"license-service/internal/domain/repositories"
 	errorInfo "license-service/pkg/log/error"
 	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+	"time"
 )
 
 type LicenseVerifierUseCase struct {
 	licenseRepository repositories.LicenseRepository
-	logger            logger.Logger
+	now               func() time.Time
 }
 
 func NewLicenseVerifierUseCase(licenseRepository repositories.LicenseRepository) contrats.LicenseVerifier {
 	return &LicenseVerifierUseCase{
 		licenseRepository: licenseRepository,
-		logger:            *logger.NewLogger(),
+		now:               time.Now,
 	}
 }
 
-func (usecase *LicenseVerifierUseCase) Execute(ctx context.Context, folio string) (bool, error) {
-	usecase.logger.Info(
+// Execute siempre devuelve un resultado: un folio inexistente es una
+// verificación con motivo not_found, no un error.
+func (usecase *LicenseVerifierUseCase) Execute(ctx context.Context, folio string) (_ *dto.LicenseVerificationDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicenseVerifierUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicenseVerifierUseCase", err)
+	}()
+
+	logger.FromContext(ctx).Info(
 		"LicenseVerifierUseCase",
 		"Execute",
 		"starting license verification process",
 	)
 
 	if err := usecase.validateFolio(folio); err != nil {
-		usecase.logger.Error(
+		logger.FromContext(ctx).Error(
 			"LicenseVerifierUseCase",
 			"Execute",
 			err,
 			"validation failed",
 		)
-		return false, err
+		return nil, err
 	}
 
 	license, err := usecase.licenseRepository.FindByFolio(ctx, folio)
 	if err != nil {
-		usecase.logger.Error(
+		logger.FromContext(ctx).Error(
 			"LicenseVerifierUseCase",
 			"Execute",
 			err,
 			"failed to retrieve license from repository",
 		)
-		return false, err
+		return nil, err
 	}
 
+	now := usecase.now()
 	if license == nil {
-		usecase.logger.Info(
+		logger.FromContext(ctx).Info(
 			"LicenseVerifierUseCase",
 			"Execute",
 			"license not found",
 		)
-		return false, nil
+		metrics.LicenseVerificationsTotal.WithLabelValues(model.VerificationNotFound).Inc()
+		return mapper.ToNotFoundVerificationDTO(folio, now), nil
 	}
 
-	isIssued := license.IsIssued()
+	verification := mapper.ToLicenseVerificationDTO(license, now)
+	metrics.LicenseVerificationsTotal.WithLabelValues(verification.Reason).Inc()
 
-	if isIssued {
-		usecase.logger.Info(
+	if verification.Valid {
+		logger.FromContext(ctx).Info(
 			"LicenseVerifierUseCase",
 			"Execute",
-			"license verification successful - valid license",
+			"license verification successful - valid license, reason: "+verification.Reason,
 		)
 	} else {
-		usecase.logger.Info(
+		logger.FromContext(ctx).Info(
 			"LicenseVerifierUseCase",
 			"Execute",
-			"license found but not issued",
+			"license found but not valid, reason: "+verification.Reason,
 		)
 	}
-	return isIssued, nil
+	return verification, nil
 }
 
 func (usecase *LicenseVerifierUseCase) validateFolio(folio string) error {
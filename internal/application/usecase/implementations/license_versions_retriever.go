@@ -0,0 +1,128 @@
+package implementations
+
+import (
+	"context"
+	"fmt"
+	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
+	"license-service/internal/application/usecase/contrats"
+	model "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
+)
+
+type LicenseVersionsRetrieverUseCase struct {
+	licenseRepository repositories.LicenseRepository
+}
+
+func NewLicenseVersionsRetrieverUseCase(licenseRepository repositories.LicenseRepository) contrats.LicenseVersionsRetriever {
+	return &LicenseVersionsRetrieverUseCase{
+		licenseRepository: licenseRepository,
+	}
+}
+
+func (usecase *LicenseVersionsRetrieverUseCase) Execute(ctx context.Context, folio string) (_ []*dto.LicenseVersionDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicenseVersionsRetrieverUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicenseVersionsRetrieverUseCase", err)
+	}()
+
+	logger.FromContext(ctx).Info("LicenseVersionsRetrieverUseCase", "Execute", "retrieving versions of folio: "+folio)
+
+	versions, err := usecase.licenseRepository.FindVersions(ctx, folio)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseVersionsRetrieverUseCase", "Execute", err, "failed to retrieve versions from repository")
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		// Licencia emitida antes del historial (o inexistente): su única versión
+		// es el estado actual.
+		license, err := findLicense(ctx, usecase.licenseRepository, "LicenseVersionsRetrieverUseCase", folio)
+		if err != nil {
+			return nil, err
+		}
+		versions = []*model.LicenseVersion{license.IssuedVersion()}
+	}
+
+	logger.FromContext(ctx).Info("LicenseVersionsRetrieverUseCase", "Execute", "versions retrieved successfully for folio: "+folio, "count", len(versions))
+	return mapper.ToLicenseVersionDTOs(versions), nil
+}
+
+type LicenseVersionRetrieverUseCase struct {
+	licenseRepository repositories.LicenseRepository
+}
+
+func NewLicenseVersionRetrieverUseCase(licenseRepository repositories.LicenseRepository) contrats.LicenseVersionRetriever {
+	return &LicenseVersionRetrieverUseCase{
+		licenseRepository: licenseRepository,
+	}
+}
+
+// Execute devuelve la licencia tal como quedó en la versión pedida.
+func (usecase *LicenseVersionRetrieverUseCase) Execute(ctx context.Context, folio string, version int) (_ *dto.LicenseDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicenseVersionRetrieverUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicenseVersionRetrieverUseCase", err)
+	}()
+
+	logger.FromContext(ctx).Info("LicenseVersionRetrieverUseCase", "Execute", fmt.Sprintf("retrieving version %d of folio: %s", version, folio))
+
+	if version < 1 {
+		appErr := errorInfo.NewAppError(
+			errorInfo.ErrInvalidData,
+			"LicenseVersionRetrieverUseCase",
+			"Execute",
+			"version must be greater than 0",
+		)
+		logger.FromContext(ctx).Error("LicenseVersionRetrieverUseCase", "Execute", appErr, "invalid version")
+		return nil, appErr
+	}
+
+	licenseVersion, err := usecase.licenseRepository.FindVersion(ctx, folio, version)
+	if err != nil {
+		logger.FromContext(ctx).Error("LicenseVersionRetrieverUseCase", "Execute", err, "failed to retrieve version from repository")
+		return nil, err
+	}
+	if licenseVersion != nil {
+		return mapper.ToLicenseDTO(&licenseVersion.Snapshot), nil
+	}
+
+	license, err := findLicense(ctx, usecase.licenseRepository, "LicenseVersionRetrieverUseCase", folio)
+	if err != nil {
+		return nil, err
+	}
+	// Sin historial guardado, la versión 1 de una licencia nunca enmendada es la actual.
+	if version == 1 && license.CurrentVersion() == 1 {
+		return mapper.ToLicenseDTO(license), nil
+	}
+
+	appErr := errorInfo.NewAppError(
+		errorInfo.ErrNotFound,
+		"LicenseVersionRetrieverUseCase",
+		"Execute",
+		"license version not found",
+	)
+	logger.FromContext(ctx).Info("LicenseVersionRetrieverUseCase", "Execute", fmt.Sprintf("version %d not found for folio: %s", version, folio))
+	return nil, appErr
+}
+
+// findLicense busca una licencia y traduce su ausencia a ErrNotFound.
+func findLicense(ctx context.Context, licenseRepository repositories.LicenseRepository, component, folio string) (*model.License, error) {
+	license, err := licenseRepository.FindByFolio(ctx, folio)
+	if err != nil {
+		logger.FromContext(ctx).Error(component, "Execute", err, "failed to retrieve license from repository")
+		return nil, err
+	}
+
+	if license == nil {
+		logger.FromContext(ctx).Info(component, "Execute", "license not found for folio: "+folio)
+		return nil, errorInfo.NewAppError(errorInfo.ErrNotFound, component, "Execute", "license not found")
+	}
+	return license, nil
+}
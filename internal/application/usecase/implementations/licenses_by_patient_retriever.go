@@ -3,26 +3,35 @@ package implementations
 import (
 	"context"
 	"license-service/internal/application/dto"
+	"license-service/internal/application/mapper"
 	"license-service/internal/application/usecase/contrats"
 	"license-service/internal/domain/repositories"
+	valueobject "license-service/internal/domain/valueobject"
 	errorInfo "license-service/pkg/log/error"
 	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+	"license-service/pkg/telemetry"
 )
 
 type LicensesByPatientRetrieverUseCase struct {
 	licenseRepository repositories.LicenseRepository
-	logger            logger.Logger
 }
 
 func NewLicensesByPatientRetrieverUseCase(licenseRepository repositories.LicenseRepository) contrats.LicensesByPatientRetriever {
 	return &LicensesByPatientRetrieverUseCase{
 		licenseRepository: licenseRepository,
-		logger:            *logger.NewLogger(),
 	}
 }
 
-func (usecase *LicensesByPatientRetrieverUseCase) Execute(ctx context.Context, patientID string) ([]*dto.LicenseDTO, error) {
-	usecase.logger.Info("LicensesByPatientRetrieverUseCase", "Execute", "retrieving licenses for patient: "+patientID)
+func (usecase *LicensesByPatientRetrieverUseCase) Execute(ctx context.Context, filter dto.LicenseFilterDTO) (_ []*dto.LicenseDTO, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "LicensesByPatientRetrieverUseCase.Execute")
+	defer func() {
+		telemetry.EndSpan(span, err)
+		metrics.ObserveUseCase("LicensesByPatientRetrieverUseCase", err)
+	}()
+
+	patientID := filter.PatientID
+	logger.FromContext(ctx).Info("LicensesByPatientRetrieverUseCase", "Execute", "retrieving licenses for patient: "+patientID)
 
 	if patientID == "" {
 		appErr := errorInfo.NewAppError(
@@ -31,30 +40,28 @@ func (usecase *LicensesByPatientRetrieverUseCase) Execute(ctx context.Context, p
 			"Execute",
 			"patientID is required",
 		)
-		usecase.logger.Error("LicensesByPatientRetrieverUseCase", "Execute", appErr, "empty patientID provided")
+		logger.FromContext(ctx).Error("LicensesByPatientRetrieverUseCase", "Execute", appErr, "empty patientID provided")
 		return nil, appErr
 	}
 
-	licenses, err := usecase.licenseRepository.FindByPatientID(ctx, patientID)
+	if filter.Type != "" {
+		if _, err = valueobject.NewLicenseType(filter.Type); err != nil {
+			logger.FromContext(ctx).Error("LicensesByPatientRetrieverUseCase", "Execute", err, "invalid type filter")
+			return nil, err
+		}
+	}
+
+	licenses, err := usecase.licenseRepository.FindByCriteria(ctx, repositories.LicenseCriteria{
+		PatientID: patientID,
+		Type:      filter.Type,
+	})
 	if err != nil {
-		usecase.logger.Error("LicensesByPatientRetrieverUseCase", "Execute", err, "failed to retrieve licenses from repository")
+		logger.FromContext(ctx).Error("LicensesByPatientRetrieverUseCase", "Execute", err, "failed to retrieve licenses from repository")
 		return nil, err
 	}
 
-	licenseDTOs := make([]*dto.LicenseDTO, 0, len(licenses))
-	for _, license := range licenses {
-		licenseDTO := &dto.LicenseDTO{
-			Folio:     license.Folio,
-			PatientID: license.PatientID,
-			DoctorID:  license.DoctorID,
-			Diagnosis: license.Diagnosis,
-			StartDate: license.StartDate.Format("2006-01-02"),
-			Days:      license.Days,
-			Status:    license.Status,
-		}
-		licenseDTOs = append(licenseDTOs, licenseDTO)
-	}
+	licenseDTOs := mapper.ToLicenseDTOs(licenses)
 
-	usecase.logger.Info("LicensesByPatientRetrieverUseCase", "Execute", "retrieved licenses successfully for patient: "+patientID, "count", len(licenseDTOs))
+	logger.FromContext(ctx).Info("LicensesByPatientRetrieverUseCase", "Execute", "retrieved licenses successfully for patient: "+patientID, "count", len(licenseDTOs))
 	return licenseDTOs, nil
 }
@@ -0,0 +1,131 @@
+package bootstrap
+
+import (
+	"license-service/internal/application/policy"
+	"license-service/internal/application/usecase/implementations"
+	persistenceRepo "license-service/internal/persistence/repositories"
+	"license-service/internal/presentation/controller"
+	"license-service/internal/presentation/router"
+	"license-service/pkg/cache"
+	"license-service/pkg/circuitbreaker"
+	"license-service/pkg/env"
+	"license-service/pkg/metrics"
+)
+
+// RepositoryModule crea las implementaciones GORM de los repositorios que no
+// hayan sido asignados antes (por ejemplo, fakes en pruebas).
+var RepositoryModule = Module{
+	Name: "repositories",
+	Provide: func(c *Container) error {
+		queryTimeout := c.Config.Database.QueryTimeout
+
+		if c.Repositories.Licenses == nil {
+			c.Repositories.Licenses = persistenceRepo.NewLicenseRepositoryImpl(c.DB, queryTimeout)
+			if c.Config.Database.CircuitBreaker.Enabled {
+				c.Repositories.Licenses = persistenceRepo.NewCircuitBreakerLicenseRepository(c.Repositories.Licenses, newDatabaseBreaker(c))
+			}
+			// La caché va por fuera del circuit breaker para seguir respondiendo
+			// lecturas cacheadas mientras la base está caída.
+			if c.Config.Cache.Enabled {
+				// El listener de avisos comparte este Guard, así sus invalidaciones
+				// también descartan las lecturas de la base que estén en curso.
+				c.LicenseCache = cache.NewGuard(newCacheBackend(c.Config.Cache))
+				c.Repositories.Licenses = persistenceRepo.NewCachingLicenseRepository(
+					c.Repositories.Licenses,
+					c.LicenseCache,
+					c.Config.Cache.TTL,
+					c.Config.Cache.NegativeTTL,
+				)
+			}
+		}
+		if c.Repositories.APIKeys == nil {
+			c.Repositories.APIKeys = persistenceRepo.NewAPIKeyRepositoryImpl(c.DB, queryTimeout)
+		}
+		if c.Repositories.ExportJobs == nil {
+			c.Repositories.ExportJobs = persistenceRepo.NewExportJobRepositoryImpl(c.DB, queryTimeout)
+		}
+		if c.Repositories.ExportFiles == nil {
+			c.Repositories.ExportFiles = persistenceRepo.NewFileExportStore(c.Config.Export.Dir)
+		}
+		return nil
+	},
+}
+
+// UseCaseModule arma los casos de uso sobre los repositorios del contenedor. Los
+// de licencias quedan envueltos por la política de acceso.
+var UseCaseModule = Module{
+	Name: "usecases",
+	Provide: func(c *Container) error {
+		licenseRepo := c.Repositories.Licenses
+		apiKeyRepo := c.Repositories.APIKeys
+		exportJobs := c.Repositories.ExportJobs
+		exportFiles := c.Repositories.ExportFiles
+		licensePolicy := policy.NewLicensePolicy()
+		exportSettings := implementations.ExportSettings{
+			PageSize:       c.Config.Export.PageSize,
+			PseudonymKey:   c.Config.Export.PseudonymKey,
+			JobTTL:         c.Config.Export.JobTTL,
+			MaxRunningJobs: c.Config.Export.MaxRunningJobs,
+		}
+
+		c.UseCases.Licenses = controller.LicenseUseCases{
+			Issuer:             policy.NewAuthorizedLicenseIssuer(implementations.NewIssueLicenseUseCase(licenseRepo), licensePolicy),
+			Retriever:          policy.NewAuthorizedLicenseRetriever(implementations.NewLicenseRetrieverUseCase(licenseRepo), licensePolicy),
+			Verifier:           policy.NewAuthorizedLicenseVerifier(implementations.NewLicenseVerifierUseCase(licenseRepo), licensePolicy),
+			BatchVerifier:      policy.NewAuthorizedLicenseBatchVerifier(implementations.NewLicenseBatchVerifierUseCase(licenseRepo, c.Config.Verification.BatchMaxSize), licensePolicy),
+			ByPatientRetriever: policy.NewAuthorizedLicensesByPatientRetriever(implementations.NewLicensesByPatientRetrieverUseCase(licenseRepo), licensePolicy),
+			Resolver:           policy.NewAuthorizedLicenseResolver(implementations.NewLicenseResolverUseCase(licenseRepo), licensePolicy),
+			VersionRetriever:   policy.NewAuthorizedLicenseVersionRetriever(implementations.NewLicenseVersionRetrieverUseCase(licenseRepo), licensePolicy),
+			VersionsRetriever:  policy.NewAuthorizedLicenseVersionsRetriever(implementations.NewLicenseVersionsRetrieverUseCase(licenseRepo), licensePolicy),
+			Amender:            policy.NewAuthorizedLicenseAmender(implementations.NewLicenseAmenderUseCase(licenseRepo), licensePolicy),
+			Importer:           policy.NewAuthorizedLicenseImporter(implementations.NewLicenseImportUseCase(licenseRepo, c.Config.Import.ChunkSize), licensePolicy),
+			Exporter:           policy.NewAuthorizedLicenseExporter(implementations.NewLicenseExporterUseCase(licenseRepo, exportSettings), licensePolicy),
+			ExportJobStarter: policy.NewAuthorizedExportJobStarter(
+				implementations.NewExportJobStarterUseCase(licenseRepo, exportJobs, exportFiles, c.Workers.Go, exportSettings),
+				licensePolicy,
+			),
+			ExportJobRetriever:  policy.NewAuthorizedExportJobRetriever(implementations.NewExportJobRetrieverUseCase(exportJobs), licensePolicy),
+			ExportJobDownloader: policy.NewAuthorizedExportJobDownloader(implementations.NewExportJobDownloaderUseCase(exportJobs, exportFiles), licensePolicy),
+		}
+
+		c.UseCases.Employers = controller.EmployerUseCases{
+			LicensesRetriever: policy.NewAuthorizedEmployerLicensesRetriever(implementations.NewEmployerLicensesRetrieverUseCase(licenseRepo), licensePolicy),
+			Acknowledger:      policy.NewAuthorizedEmployerAcknowledger(implementations.NewEmployerAcknowledgerUseCase(licenseRepo), licensePolicy),
+		}
+
+		c.UseCases.APIKeys = controller.APIKeyUseCases{
+			Creator: implementations.NewAPIKeyCreatorUseCase(apiKeyRepo, router.RouteNames),
+			Lister:  implementations.NewAPIKeyListerUseCase(apiKeyRepo),
+			Rotator: implementations.NewAPIKeyRotatorUseCase(apiKeyRepo),
+			Revoker: implementations.NewAPIKeyRevokerUseCase(apiKeyRepo),
+		}
+		c.UseCases.APIKeyAuthenticator = implementations.NewAPIKeyAuthenticatorUseCase(apiKeyRepo)
+		c.UseCases.LicenseRevoker = implementations.NewLicenseRevokerUseCase(licenseRepo)
+		c.UseCases.LicenseExpirer = implementations.NewLicenseExpirerUseCase(licenseRepo)
+		c.UseCases.ExportJobPurger = implementations.NewExportJobPurgerUseCase(exportJobs, exportFiles, exportSettings.JobTTL)
+		return nil
+	},
+}
+
+func newDatabaseBreaker(c *Container) *circuitbreaker.Breaker {
+	config := c.Config.Database.CircuitBreaker
+	metrics.CircuitBreakerState.WithLabelValues("postgres").Set(float64(circuitbreaker.StateClosed))
+
+	return circuitbreaker.New(circuitbreaker.Config{
+		FailureThreshold: config.FailureThreshold,
+		OpenTimeout:      config.OpenTimeout,
+		HalfOpenMaxCalls: config.HalfOpenMaxCalls,
+		IsFailure:        persistenceRepo.IsInfrastructureFailure,
+		OnStateChange: func(from, to circuitbreaker.State) {
+			metrics.CircuitBreakerState.WithLabelValues("postgres").Set(float64(to))
+			c.Logger.Warn("Bootstrap", "circuitBreaker", "postgres circuit breaker "+from.String()+" -> "+to.String())
+		},
+	})
+}
+
+// newCacheBackend crea el backend configurado. Por ahora solo existe "memory";
+// un backend externo se agrega implementando cache.Backend y eligiéndolo aquí
+// según CACHE_BACKEND.
+func newCacheBackend(config env.CacheConfig) cache.Backend {
+	return cache.NewLRU(config.MaxEntries)
+}
@@ -0,0 +1,125 @@
+package bootstrap
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"license-service/internal/application/usecase/contrats"
+	"license-service/internal/domain/repositories"
+	"license-service/internal/presentation/controller"
+	"license-service/pkg/cache"
+	"license-service/pkg/env"
+	"license-service/pkg/health"
+	"license-service/pkg/lifecycle"
+	errorInfo "license-service/pkg/log/error"
+	logs "license-service/pkg/log/logger"
+	"license-service/pkg/worker"
+
+	"gorm.io/gorm"
+)
+
+// Container es la raíz de composición: cada Module completa los campos que le
+// corresponden y registra sus hooks de arranque/parada. Un módulo no pisa lo que
+// ya está asignado, así que en pruebas basta con fijar fakes antes de Build.
+type Container struct {
+	Config    *env.Config
+	LogOutput io.Writer
+	Logger    *logs.Logger
+	Lifecycle *lifecycle.Lifecycle
+	Workers   *worker.Group
+	Health    *health.Registry
+
+	DB           *gorm.DB
+	Repositories Repositories
+	// LicenseCache es la caché de licencias; nil si está deshabilitada.
+	LicenseCache *cache.Guard
+	UseCases     UseCases
+
+	Handler http.Handler
+	Server  *http.Server
+
+	serverErr chan error
+}
+
+type Repositories struct {
+	Licenses    repositories.LicenseRepository
+	APIKeys     repositories.APIKeyRepository
+	ExportJobs  repositories.ExportJobRepository
+	ExportFiles repositories.ExportFileStore
+}
+
+type UseCases struct {
+	Licenses            controller.LicenseUseCases
+	APIKeys             controller.APIKeyUseCases
+	Employers           controller.EmployerUseCases
+	APIKeyAuthenticator contrats.APIKeyAuthenticator
+	LicenseRevoker      contrats.LicenseRevoker
+	LicenseExpirer      contrats.LicenseExpirer
+	ExportJobPurger     contrats.ExportJobPurger
+}
+
+// Module construye un subsistema sobre el contenedor.
+type Module struct {
+	Name    string
+	Provide func(c *Container) error
+}
+
+func NewContainer(config *env.Config) *Container {
+	return &Container{
+		Config:    config,
+		Lifecycle: lifecycle.New(),
+		Workers:   worker.NewGroup(),
+		serverErr: make(chan error, 1),
+	}
+}
+
+// DefaultModules es el grafo completo del servicio en orden de dependencias.
+func DefaultModules() []Module {
+	return []Module{
+		ObservabilityModule,
+		DatabaseModule,
+		RepositoryModule,
+		UseCaseModule,
+		HTTPModule,
+	}
+}
+
+func Build(c *Container, modules ...Module) error {
+	for _, module := range modules {
+		if err := module.Provide(c); err != nil {
+			return errorInfo.WrapError(errorInfo.ErrServiceConfig, "Bootstrap", "Build", "failed to provide module "+module.Name, err)
+		}
+	}
+	return nil
+}
+
+// Run arranca todos los hooks, espera a que ctx termine o a que el servidor
+// falle y luego detiene todo dentro de SERVER_SHUTDOWN_TIMEOUT.
+func (c *Container) Run(ctx context.Context) error {
+	if err := c.Lifecycle.Start(ctx); err != nil {
+		return errorInfo.WrapError(errorInfo.ErrServiceConfig, "Bootstrap", "Run", "failed to start services", err)
+	}
+
+	var runErr error
+	select {
+	case runErr = <-c.serverErr:
+		c.Logger.Error("Bootstrap", "Run", runErr, "HTTP server stopped unexpectedly")
+	case <-ctx.Done():
+		c.Logger.Info("Bootstrap", "Run", "Shutdown signal received, draining in-flight requests")
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), c.Config.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := c.Lifecycle.Stop(stopCtx); err != nil {
+		shutdownErr := errorInfo.WrapError(errorInfo.ErrGracefulShutdown, "Bootstrap", "Run", "services did not stop cleanly before the deadline", err)
+		c.Logger.Error("Bootstrap", "Run", shutdownErr)
+		if runErr == nil {
+			runErr = shutdownErr
+		}
+	}
+
+	c.Logger.Info("Bootstrap", "Run", "Server stopped")
+	return runErr
+}
@@ -0,0 +1,89 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	database "license-service/internal/persistence/configuration"
+	"license-service/pkg/backoff"
+	"license-service/pkg/lifecycle"
+	errorInfo "license-service/pkg/log/error"
+)
+
+// DatabaseModule abre el pool de PostgreSQL, aplica migraciones y registra los
+// checks de salud. El pool se cierra al final del apagado. Con DB_LAZY_CONNECT
+// el servicio arranca aunque la base no responda y reconecta en la primera
+// consulta que lo logre.
+var DatabaseModule = Module{
+	Name: "database",
+	Provide: func(c *Container) error {
+		reachable := true
+		if c.DB == nil {
+			db, err := database.NewConnection(c.Config)
+			if err != nil {
+				return err
+			}
+			c.DB = db
+
+			if err := connectWithRetry(c); err != nil {
+				if !c.Config.Database.LazyConnect {
+					database.Close(db)
+					return err
+				}
+				reachable = false
+				c.Logger.Warn("Bootstrap", "DatabaseModule", "Database unreachable, starting anyway (DB_LAZY_CONNECT=true); migrations skipped")
+			}
+		}
+
+		if c.Config.Database.AutoMigrate && reachable {
+			if err := database.RunMigrations(c.DB); err != nil {
+				return err
+			}
+		}
+
+		c.Health.Register(database.NewDatabaseChecker(c.DB))
+		c.Health.Register(database.NewLicensesTableChecker(c.DB))
+
+		db := c.DB
+		c.Lifecycle.Append(lifecycle.Hook{
+			Name:   "database",
+			OnStop: func(context.Context) error { return database.Close(db) },
+		})
+		return nil
+	},
+}
+
+// connectWithRetry espera a que la base responda, reintentando con backoff
+// exponencial y jitter hasta DB_CONNECT_MAX_ATTEMPTS.
+func connectWithRetry(c *Container) error {
+	config := c.Config.Database
+	delays := backoff.Exponential{
+		Initial:    config.ConnectInitialBackoff,
+		Max:        config.ConnectMaxBackoff,
+		Multiplier: 2,
+	}
+
+	var err error
+	for attempt := 0; attempt < config.ConnectMaxAttempts; attempt++ {
+		c.Logger.Info("Bootstrap", "connectWithRetry", fmt.Sprintf("Database connection attempt %d/%d", attempt+1, config.ConnectMaxAttempts))
+
+		ctx, cancel := context.WithTimeout(context.Background(), config.QueryTimeout)
+		err = database.Ping(ctx, c.DB)
+		cancel()
+		if err == nil {
+			c.Logger.Info("Bootstrap", "connectWithRetry", "Database connection successful")
+			return nil
+		}
+
+		c.Logger.Error("Bootstrap", "connectWithRetry", err, fmt.Sprintf("Connection attempt %d failed", attempt+1))
+
+		if attempt < config.ConnectMaxAttempts-1 {
+			delay := delays.Delay(attempt)
+			c.Logger.Info("Bootstrap", "connectWithRetry", fmt.Sprintf("Retrying in %v...", delay.Round(time.Millisecond)))
+			time.Sleep(delay)
+		}
+	}
+
+	return errorInfo.WrapError(errorInfo.ErrDBConnection, "Bootstrap", "connectWithRetry", fmt.Sprintf("failed to connect to database after %d attempts", config.ConnectMaxAttempts), err)
+}
@@ -0,0 +1,161 @@
+package bootstrap
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"license-service/internal/application/usecase/contrats"
+	persistenceRepo "license-service/internal/persistence/repositories"
+	"license-service/internal/presentation/middleware"
+	"license-service/internal/presentation/router"
+	"license-service/internal/presentation/server"
+	"license-service/pkg/auth"
+	"license-service/pkg/env"
+	"license-service/pkg/lifecycle"
+	errorInfo "license-service/pkg/log/error"
+	logs "license-service/pkg/log/logger"
+	"license-service/pkg/ratelimit"
+	"license-service/pkg/worker"
+
+	"github.com/gorilla/mux"
+)
+
+// HTTPModule construye middlewares, router y servidor. Al detenerse primero
+// marca el servicio como no listo, luego drena las peticiones en curso y por
+// último espera a los workers de fondo.
+var HTTPModule = Module{
+	Name: "http",
+	Provide: func(c *Container) error {
+		authMiddleware, err := buildAuthMiddleware(c.Config.Auth, c.UseCases.APIKeyAuthenticator, c.Logger)
+		if err != nil {
+			return err
+		}
+
+		c.Handler = router.SetupRoutes(router.Dependencies{
+			Licenses:       c.UseCases.Licenses,
+			APIKeys:        c.UseCases.APIKeys,
+			Employers:      c.UseCases.Employers,
+			Authentication: authMiddleware,
+			RateLimit:      buildRateLimitMiddleware(c.Config.RateLimit, c.Workers, c.Logger),
+			Health:         c.Health,
+			ImportMaxBytes: int64(c.Config.Import.MaxBytes),
+		})
+		c.Server = server.NewHTTPServer(c.Config.Server, c.Handler)
+		c.Workers.Go(purgeExportJobs(c.UseCases.ExportJobPurger, c.Logger))
+		// Las escrituras de otras réplicas y de licensectl llegan como avisos de
+		// Postgres; sin esto la caché las ocultaría hasta que venza el TTL.
+		if c.LicenseCache != nil && c.DB != nil {
+			c.Workers.Go(persistenceRepo.NewLicenseChangeListener(c.DB, c.LicenseCache).Run)
+		}
+
+		c.Lifecycle.Append(lifecycle.Hook{Name: "workers", OnStop: c.Workers.Stop})
+		c.Lifecycle.Append(lifecycle.Hook{
+			Name:    "http",
+			OnStart: c.startServer,
+			OnStop:  c.Server.Shutdown,
+		})
+		c.Lifecycle.Append(lifecycle.Hook{
+			Name: "readiness",
+			OnStop: func(context.Context) error {
+				c.Health.MarkShuttingDown()
+				return nil
+			},
+		})
+		return nil
+	},
+}
+
+// startServer abre el puerto de forma síncrona para que un error de bind falle
+// el arranque, y sirve en segundo plano.
+func (c *Container) startServer(context.Context) error {
+	listener, err := net.Listen("tcp", c.Server.Addr)
+	if err != nil {
+		return err
+	}
+
+	c.Logger.Info("Bootstrap", "startServer", "Server starting on "+c.Server.Addr)
+	go func() {
+		if err := c.Server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			c.serverErr <- err
+		}
+	}()
+	return nil
+}
+
+func buildAuthMiddleware(config env.AuthConfig, apiKeys contrats.APIKeyAuthenticator, logger *logs.Logger) (mux.MiddlewareFunc, error) {
+	if !config.Enabled {
+		logger.Warn("Bootstrap", "buildAuthMiddleware", "Authentication is DISABLED, license routes are public")
+		return nil, nil
+	}
+
+	var keys auth.KeySource
+	switch {
+	case config.JWKSFile != "":
+		source, err := auth.NewFileKeySource(config.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = source
+	case config.JWKSURL != "":
+		keys = auth.NewRemoteKeySource(config.JWKSURL, nil)
+	default:
+		return nil, errorInfo.NewAppError(errorInfo.ErrServiceConfig, "Bootstrap", "buildAuthMiddleware", "AUTH_JWKS_FILE or AUTH_JWKS_URL is required when AUTH_ENABLED=true")
+	}
+
+	validator := auth.NewTokenValidator(keys, auth.TokenValidatorConfig{
+		Issuer:    config.Issuer,
+		Audience:  config.Audience,
+		ClockSkew: config.ClockSkew,
+	})
+	return middleware.Authentication(validator, apiKeys), nil
+}
+
+// exportPurgeInterval es cada cuánto se descartan las exportaciones vencidas;
+// con EXPORT_JOB_TTL del orden de horas, revisar cada minuto basta.
+const exportPurgeInterval = time.Minute
+
+// purgeExportJobs purga también al arrancar, para descartar lo que quedó de
+// una ejecución anterior.
+func purgeExportJobs(purger contrats.ExportJobPurger, logger *logs.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		purge := func(now time.Time) {
+			if _, err := purger.Execute(ctx, now); err != nil {
+				logger.Error("Bootstrap", "purgeExportJobs", err, "failed to purge expired export jobs")
+			}
+		}
+
+		ticker := time.NewTicker(exportPurgeInterval)
+		defer ticker.Stop()
+
+		purge(time.Now())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				purge(now)
+			}
+		}
+	}
+}
+
+func buildRateLimitMiddleware(config env.RateLimitConfig, workers *worker.Group, logger *logs.Logger) mux.MiddlewareFunc {
+	if !config.Enabled {
+		logger.Warn("Bootstrap", "buildRateLimitMiddleware", "Rate limiting is DISABLED")
+		return nil
+	}
+
+	store := ratelimit.NewMemoryStore(10 * time.Minute)
+	workers.Go(store.Run)
+
+	routeLimits := map[string]ratelimit.Limit{
+		router.RouteLicensesVerify:      ratelimit.PerMinute(config.Verify.RequestsPerMinute, config.Verify.Burst),
+		router.RouteLicensesVerifyBatch: ratelimit.PerMinute(config.VerifyBatch.RequestsPerMinute, config.VerifyBatch.Burst),
+		router.RouteLicensesCreate:      ratelimit.PerMinute(config.Issue.RequestsPerMinute, config.Issue.Burst),
+	}
+	defaultLimit := ratelimit.PerMinute(config.Default.RequestsPerMinute, config.Default.Burst)
+
+	return middleware.RateLimit(store, routeLimits, defaultLimit)
+}
@@ -0,0 +1,38 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"license-service/pkg/health"
+	"license-service/pkg/lifecycle"
+	logs "license-service/pkg/log/logger"
+	"license-service/pkg/telemetry"
+)
+
+// ObservabilityModule configura logs, trazas y el registro de salud.
+var ObservabilityModule = Module{
+	Name: "observability",
+	Provide: func(c *Container) error {
+		logs.Configure(logs.Options{
+			Level:   c.Config.App.LogLevel,
+			Format:  c.Config.App.LogFormat,
+			MaskPII: c.Config.App.LogMaskPII,
+			Output:  c.LogOutput,
+		})
+		if c.Logger == nil {
+			c.Logger = logs.NewLogger()
+		}
+
+		shutdownTracing, err := telemetry.SetupTracing(context.Background(), c.Config.Tracing)
+		if err != nil {
+			return err
+		}
+		c.Lifecycle.Append(lifecycle.Hook{Name: "tracing", OnStop: shutdownTracing})
+
+		if c.Health == nil {
+			c.Health = health.NewRegistry(2 * time.Second)
+		}
+		return nil
+	},
+}
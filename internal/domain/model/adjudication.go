@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	err "license-service/pkg/log/error"
+	"strings"
+	"time"
+)
+
+const (
+	AdjudicationUnderReview = "under_review"
+	AdjudicationApproved    = "approved"
+	AdjudicationReduced     = "reduced"
+	AdjudicationRejected    = "rejected"
+)
+
+// Adjudication es el pronunciamiento de la aseguradora (FONASA o ISAPRE) sobre
+// la licencia. Toda licencia emitida queda en revisión hasta que se resuelve.
+type Adjudication struct {
+	Status string
+	Reason string
+	// ApprovedDays es nil mientras la licencia está en revisión.
+	ApprovedDays *uint8
+	ResolvedAt   *time.Time
+	ResolvedBy   string
+}
+
+// Resolution es la decisión que informa la aseguradora. ApprovedDays solo se
+// usa para "reduced"; "approved" autoriza todos los días y "rejected" ninguno.
+type Resolution struct {
+	Status       string
+	Reason       string
+	ApprovedDays uint8
+	ResolvedBy   string
+}
+
+func NewAdjudication() Adjudication {
+	return Adjudication{Status: AdjudicationUnderReview}
+}
+
+func (adjudication Adjudication) IsResolved() bool {
+	return adjudication.Status != "" && adjudication.Status != AdjudicationUnderReview
+}
+
+// Resolve registra la resolución de la aseguradora. Solo se resuelve una vez y
+// nunca sobre una licencia revocada.
+func (license *License) Resolve(resolution Resolution, at time.Time) error {
+	if license.Status == StatusRevoked {
+		return err.NewAppError(err.ErrConflict, "license model", "Resolve", "revoked licenses cannot be adjudicated")
+	}
+	if license.Adjudication.IsResolved() {
+		return err.NewAppError(err.ErrConflict, "license model", "Resolve", "license is already "+license.Adjudication.Status)
+	}
+
+	reason := strings.TrimSpace(resolution.Reason)
+	var approvedDays uint8
+
+	switch resolution.Status {
+	case AdjudicationApproved:
+		approvedDays = license.Days
+	case AdjudicationReduced:
+		if resolution.ApprovedDays == 0 || resolution.ApprovedDays >= license.Days {
+			return err.NewAppError(err.ErrInvalidData, "license model", "Resolve", fmt.Sprintf("approvedDays must be between 1 and %d for a reduced license", license.Days-1))
+		}
+		approvedDays = resolution.ApprovedDays
+	case AdjudicationRejected:
+		approvedDays = 0
+	default:
+		return err.NewAppError(err.ErrInvalidData, "license model", "Resolve", "status must be approved, reduced or rejected")
+	}
+
+	if resolution.Status != AdjudicationApproved && reason == "" {
+		return err.NewAppError(err.ErrMissingRequiredField, "license model", "Resolve", "reason is required for "+resolution.Status+" licenses")
+	}
+
+	resolvedAt := at.UTC()
+	license.Adjudication = Adjudication{
+		Status:       resolution.Status,
+		Reason:       reason,
+		ApprovedDays: &approvedDays,
+		ResolvedAt:   &resolvedAt,
+		ResolvedBy:   resolution.ResolvedBy,
+	}
+	return nil
+}
+
+// EffectiveApprovedDays son los días autorizados por la aseguradora en jornadas
+// completas. El segundo valor es false mientras la licencia está en revisión.
+func (license *License) EffectiveApprovedDays() (float64, bool) {
+	if !license.Adjudication.IsResolved() || license.Adjudication.ApprovedDays == nil {
+		return 0, false
+	}
+	return float64(*license.Adjudication.ApprovedDays) * license.restDayFactor(), true
+}
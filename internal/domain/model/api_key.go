@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+const APIKeyScopeAll = "*"
+
+type APIKey struct {
+	Prefix     string
+	Hash       string
+	Name       string
+	Role       string
+	OwnerRut   string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	UsageCount int64
+}
+
+func (key *APIKey) IsRevoked() bool {
+	return key.RevokedAt != nil
+}
+
+func (key *APIKey) IsExpired(now time.Time) bool {
+	return key.ExpiresAt != nil && !now.Before(*key.ExpiresAt)
+}
+
+func (key *APIKey) IsActive(now time.Time) bool {
+	return !key.IsRevoked() && !key.IsExpired(now)
+}
+
+func (key *APIKey) AllowsScope(scope string) bool {
+	for _, allowed := range key.Scopes {
+		if allowed == APIKeyScopeAll || allowed == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (key *APIKey) Revoke(now time.Time) {
+	if key.RevokedAt == nil {
+		key.RevokedAt = &now
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// Employer es uno de los empleadores a los que se entrega la licencia. Un
+// trabajador puede tener varios; AcknowledgedAt queda en nil hasta que RR.HH.
+// confirma la recepción.
+type Employer struct {
+	Rut            string
+	AcknowledgedAt *time.Time
+}
+
+func NewEmployer(rut string) *Employer {
+	return &Employer{Rut: rut}
+}
+
+func (employer *Employer) IsAcknowledged() bool {
+	return employer.AcknowledgedAt != nil
+}
+
+// Acknowledge registra la recepción. Repetirla conserva la primera fecha.
+func (employer *Employer) Acknowledge(at time.Time) {
+	if employer.IsAcknowledged() {
+		return
+	}
+	acknowledgedAt := at.UTC()
+	employer.AcknowledgedAt = &acknowledgedAt
+}
+
+// Employer devuelve el empleador con el RUT dado, o nil si la licencia no se
+// entrega a ese empleador.
+func (license *License) Employer(rut string) *Employer {
+	for i := range license.Employers {
+		if license.Employers[i].Rut == rut {
+			return &license.Employers[i]
+		}
+	}
+	return nil
+}
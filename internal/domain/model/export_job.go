@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+const (
+	ExportJobPending   = "pending"
+	ExportJobRunning   = "running"
+	ExportJobSucceeded = "succeeded"
+	ExportJobFailed    = "failed"
+)
+
+// ExportJob es una exportación masiva que corre en segundo plano. Su archivo se
+// conserva hasta ExpiresAt; después el trabajo y el archivo se descartan.
+type ExportJob struct {
+	ID     string
+	Format string
+	// RequestedBy es el subject de quien la pidió; solo esa identidad la ve.
+	RequestedBy string
+	Status      string
+	Rows        int
+	// ErrorCode y ErrorMessage explican un trabajo fallido.
+	ErrorCode    string
+	ErrorMessage string
+	CreatedAt    time.Time
+	FinishedAt   *time.Time
+	ExpiresAt    *time.Time
+}
+
+func NewExportJob(id, format, requestedBy string, now time.Time) *ExportJob {
+	return &ExportJob{
+		ID:          id,
+		Format:      format,
+		RequestedBy: requestedBy,
+		Status:      ExportJobPending,
+		CreatedAt:   now,
+	}
+}
+
+func (job *ExportJob) Start() {
+	job.Status = ExportJobRunning
+}
+
+func (job *ExportJob) Succeed(rows int, now time.Time, ttl time.Duration) {
+	job.Status = ExportJobSucceeded
+	job.Rows = rows
+	job.finish(now, ttl)
+}
+
+func (job *ExportJob) Fail(code, message string, now time.Time, ttl time.Duration) {
+	job.Status = ExportJobFailed
+	job.ErrorCode = code
+	job.ErrorMessage = message
+	job.finish(now, ttl)
+}
+
+func (job *ExportJob) finish(now time.Time, ttl time.Duration) {
+	expiresAt := now.Add(ttl)
+	job.FinishedAt = &now
+	job.ExpiresAt = &expiresAt
+}
+
+func (job *ExportJob) IsFinished() bool {
+	return job.Status == ExportJobSucceeded || job.Status == ExportJobFailed
+}
+
+// IsExpiredAt solo aplica a trabajos terminados: uno en curso nunca vence.
+func (job *ExportJob) IsExpiredAt(now time.Time) bool {
+	return job.ExpiresAt != nil && !now.Before(*job.ExpiresAt)
+}
+
+// IsVisibleTo indica si subject puede consultar el trabajo. Sin autenticación
+// (subject y RequestedBy vacíos) todos los trabajos son visibles.
+func (job *ExportJob) IsVisibleTo(subject string) bool {
+	return job.RequestedBy == subject
+}
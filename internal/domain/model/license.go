@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	valueobject "license-service/internal/domain/valueobject"
 	err "license-service/pkg/log/error"
 	logger "license-service/pkg/log/logger"
 	"time"
@@ -21,6 +24,26 @@ type License struct {
 	StartDate time.Time
 	Status    string
 	Days      uint8
+	IssuedAt  time.Time
+	Type      string
+	Payer     string
+	// DueDate es la fecha probable de parto (solo maternidad).
+	DueDate *time.Time
+	// ChildRut es el RUT del hijo menor de un año (solo enfermedad grave del hijo).
+	ChildRut     string
+	RestType     string
+	RestLocation string
+	// RestAddress solo se informa cuando RestLocation es "other".
+	RestAddress string
+	Employers   []Employer
+	// InsurerRut es la aseguradora (FONASA o ISAPRE) que resuelve la licencia.
+	InsurerRut   string
+	Adjudication Adjudication
+	// Version es la versión vigente; aumenta con cada enmienda.
+	Version int
+	// Revision cuenta las escrituras sobre la licencia (enmiendas, revocación,
+	// recepciones, resolución) y es la base de su ETag.
+	Revision int
 }
 
 func NewLicense(license License) *License {
@@ -32,9 +55,32 @@ func NewLicense(license License) *License {
 		StartDate: license.StartDate,
 		Status:    license.Status,
 		Days:      license.Days,
+		IssuedAt:  license.IssuedAt,
+		Type:      license.Type,
+		Payer:     license.Payer,
+		DueDate:   license.DueDate,
+		ChildRut:  license.ChildRut,
+
+		RestType:     license.RestType,
+		RestLocation: license.RestLocation,
+		RestAddress:  license.RestAddress,
+		Employers:    license.Employers,
+		InsurerRut:   license.InsurerRut,
+		Adjudication: license.Adjudication,
+		Version:      license.Version,
+		Revision:     license.Revision,
 	}
 }
 
+// IsKnownStatus indica si status es uno de los estados de una licencia.
+func IsKnownStatus(status string) bool {
+	switch status {
+	case StatusIssued, StatusExpired, StatusRevoked:
+		return true
+	}
+	return false
+}
+
 func ValidateDays(days uint8) error {
 	if days <= 0 {
 		AppError := err.NewAppError(err.ErrInternalError, "license model", "validateDays", "DAy invalide")
@@ -44,8 +90,14 @@ func ValidateDays(days uint8) error {
 	return nil
 }
 
+// GenerateFolio asigna un folio "L-<segundos Unix>-<12 hex aleatorios>". El
+// prefijo conserva el orden aproximado de emisión y la parte aleatoria evita
+// choques entre licencias del mismo segundo, entre réplicas y tras reiniciar,
+// sin coordinar un contador.
 func (license *License) GenerateFolio() {
-	license.Folio = fmt.Sprintf("L-%d", time.Now().Unix())
+	suffix := make([]byte, 6)
+	_, _ = rand.Read(suffix)
+	license.Folio = fmt.Sprintf("L-%d-%s", time.Now().Unix(), hex.EncodeToString(suffix))
 }
 
 func (license *License) SetDefaultStatus(status string) {
@@ -69,3 +121,78 @@ func (license *License) IsValid() error {
 func (license *License) IsIssued() bool {
 	return license.Status == "issued"
 }
+
+// EffectiveDays son los días de reposo en jornadas completas: un reposo parcial
+// (media jornada) cuenta la mitad de cada día.
+func (license *License) EffectiveDays() float64 {
+	return float64(license.Days) * license.restDayFactor()
+}
+
+func (license *License) restDayFactor() float64 {
+	restType, restErr := valueobject.NewRestType(license.RestType)
+	if restErr != nil {
+		return 1
+	}
+	return restType.DayFactor()
+}
+
+func (license *License) EndDate() time.Time {
+	return license.StartDate.AddDate(0, 0, int(license.Days)-1)
+}
+
+// AuthorizedDays son los días de reposo que rigen: los aprobados si la
+// aseguradora redujo la licencia, o todos los emitidos.
+func (license *License) AuthorizedDays() uint8 {
+	if license.Adjudication.Status == AdjudicationReduced && license.Adjudication.ApprovedDays != nil {
+		return *license.Adjudication.ApprovedDays
+	}
+	return license.Days
+}
+
+// ValidUntil es el último día de reposo autorizado. Coincide con EndDate salvo
+// en las licencias reducidas.
+func (license *License) ValidUntil() time.Time {
+	return license.StartDate.AddDate(0, 0, int(license.AuthorizedDays())-1)
+}
+
+// CurrentRevision trata como revisión 1 a las licencias guardadas antes de que
+// existiera el contador.
+func (license *License) CurrentRevision() int {
+	if license.Revision < 1 {
+		return 1
+	}
+	return license.Revision
+}
+
+// CheckRevision falla si la licencia ya no está en la revisión que leyó el
+// cliente. expected 0 no exige ninguna.
+func (license *License) CheckRevision(expected int) error {
+	if expected != 0 && expected != license.CurrentRevision() {
+		return err.NewAppError(err.ErrPreconditionFailed, "license model", "CheckRevision", fmt.Sprintf("license is at revision %d, not %d; reload it and retry", license.CurrentRevision(), expected))
+	}
+	return nil
+}
+
+// Revoke anula una licencia emitida. Una licencia vencida o ya revocada no cambia.
+func (license *License) Revoke() error {
+	if license.Status != StatusIssued {
+		return err.NewAppError(err.ErrConflict, "license model", "Revoke", "only issued licenses can be revoked, current status: "+license.Status)
+	}
+	license.Status = StatusRevoked
+	return nil
+}
+
+// IsExpiredAt indica si una licencia emitida ya terminó su reposo autorizado en
+// la fecha dada.
+func (license *License) IsExpiredAt(now time.Time) bool {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return license.IsIssued() && license.ValidUntil().Before(today)
+}
+
+func (license *License) Expire(now time.Time) bool {
+	if !license.IsExpiredAt(now) {
+		return false
+	}
+	license.Status = StatusExpired
+	return true
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	err "license-service/pkg/log/error"
+	"strings"
+	"time"
+)
+
+const IssuedVersionReason = "issued"
+
+// LicenseVersion es una versión inmutable de la licencia. La versión 1 es la
+// emitida; cada enmienda agrega una nueva con su autor, motivo y los campos que
+// cambiaron. Snapshot es la licencia completa tal como quedó en esa versión.
+type LicenseVersion struct {
+	Folio     string
+	Version   int
+	Author    string
+	Reason    string
+	Changes   []FieldChange
+	Snapshot  License
+	CreatedAt time.Time
+}
+
+type FieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// IssuedVersion es la versión 1 de la licencia, la que firmó el médico al emitirla.
+func (license *License) IssuedVersion() *LicenseVersion {
+	snapshot := *NewLicense(*license)
+	snapshot.Version = 1
+	return &LicenseVersion{
+		Folio:     license.Folio,
+		Version:   1,
+		Author:    license.DoctorID,
+		Reason:    IssuedVersionReason,
+		Snapshot:  snapshot,
+		CreatedAt: license.IssuedAt,
+	}
+}
+
+// Amend corrige los campos enmendables (diagnóstico y reposo) a partir de
+// amended y devuelve la nueva versión. El paciente nunca cambia y solo se
+// enmiendan licencias vigentes.
+func (license *License) Amend(amended License, author, reason string, at time.Time) (*LicenseVersion, error) {
+	if amended.PatientID != "" && amended.PatientID != license.PatientID {
+		return nil, err.NewAppError(err.ErrInvalidData, "license model", "Amend", "the patient of a license cannot be amended")
+	}
+	if license.Status != StatusIssued {
+		return nil, err.NewAppError(err.ErrConflict, "license model", "Amend", "only issued licenses can be amended, current status: "+license.Status)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, err.NewAppError(err.ErrMissingRequiredField, "license model", "Amend", "reason is required")
+	}
+
+	changes := make([]FieldChange, 0, 4)
+	diff := func(field string, current *string, value string) {
+		if *current != value {
+			changes = append(changes, FieldChange{Field: field, From: *current, To: value})
+			*current = value
+		}
+	}
+	diff("diagnosis", &license.Diagnosis, amended.Diagnosis)
+	diff("restType", &license.RestType, amended.RestType)
+	diff("restLocation", &license.RestLocation, amended.RestLocation)
+	diff("restAddress", &license.RestAddress, amended.RestAddress)
+
+	if len(changes) == 0 {
+		return nil, err.NewAppError(err.ErrInvalidData, "license model", "Amend", "amendment does not change any field")
+	}
+
+	license.Version = license.CurrentVersion() + 1
+	return &LicenseVersion{
+		Folio:     license.Folio,
+		Version:   license.Version,
+		Author:    author,
+		Reason:    reason,
+		Changes:   changes,
+		Snapshot:  *NewLicense(*license),
+		CreatedAt: at.UTC(),
+	}, nil
+}
+
+// CurrentVersion trata como versión 1 a las licencias emitidas antes de que
+// existiera el historial.
+func (license *License) CurrentVersion() int {
+	if license.Version < 1 {
+		return 1
+	}
+	return license.Version
+}
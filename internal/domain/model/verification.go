@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// Motivos del resultado de verificar una licencia. Solo "valid" y
+// "pending_review" son válidos: mientras la aseguradora no resuelve, la
+// licencia emitida rige por todos sus días y el empleador debe respetarla; el
+// motivo distinto permite a quien verifica saber que aún puede reducirse o
+// rechazarse.
+const (
+	VerificationValid         = "valid"
+	VerificationPendingReview = "pending_review"
+	VerificationNotStarted    = "not_started"
+	VerificationExpired       = "expired"
+	VerificationRevoked       = "revoked"
+	VerificationRejected      = "rejected"
+	VerificationNotFound      = "not_found"
+)
+
+// VerificationReason explica si la licencia es válida en now. Una licencia
+// emitida cuyo reposo autorizado ya terminó cuenta como vencida aunque el
+// barrido de vencimientos aún no la haya marcado; una cuyo reposo aún no
+// empieza no es válida todavía.
+func (license *License) VerificationReason(now time.Time) string {
+	switch {
+	case license.Status == StatusRevoked:
+		return VerificationRevoked
+	case license.Adjudication.Status == AdjudicationRejected:
+		return VerificationRejected
+	case license.Status == StatusExpired || license.IsExpiredAt(now):
+		return VerificationExpired
+	case utcDay(now).Before(utcDay(license.StartDate)):
+		return VerificationNotStarted
+	case license.Adjudication.Status == AdjudicationApproved || license.Adjudication.Status == AdjudicationReduced:
+		return VerificationValid
+	default:
+		return VerificationPendingReview
+	}
+}
+
+// IsValidReason indica si un motivo de verificación corresponde a una licencia válida.
+func IsValidReason(reason string) bool {
+	return reason == VerificationValid || reason == VerificationPendingReview
+}
+
+// DaysRemainingAt cuenta los días de reposo autorizado que quedan desde now,
+// incluido el día de hoy. Antes del inicio son todos los días autorizados.
+func (license *License) DaysRemainingAt(now time.Time) int {
+	today := utcDay(now)
+	if today.Before(utcDay(license.StartDate)) {
+		return int(license.AuthorizedDays())
+	}
+	remaining := int(utcDay(license.ValidUntil()).Sub(today).Hours()/24) + 1
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func utcDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	models "license-service/internal/domain/model"
+	"time"
+)
+
+type APIKeyRepository interface {
+	Save(ctx context.Context, key *models.APIKey) error
+	FindByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	FindAll(ctx context.Context) ([]*models.APIKey, error)
+	Update(ctx context.Context, key *models.APIKey) error
+	RecordUsage(ctx context.Context, prefix string, usedAt time.Time) error
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"io"
+	models "license-service/internal/domain/model"
+	"time"
+)
+
+type ExportJobRepository interface {
+	Save(ctx context.Context, job *models.ExportJob) error
+	FindByID(ctx context.Context, id string) (*models.ExportJob, error)
+	Update(ctx context.Context, job *models.ExportJob) error
+	// DeleteExpired quita los trabajos vencidos en now y los que siguen sin
+	// terminar desde antes de unfinishedBefore (su proceso murió), y los
+	// devuelve para que se borren sus archivos.
+	DeleteExpired(ctx context.Context, now time.Time, unfinishedBefore time.Time) ([]*models.ExportJob, error)
+}
+
+// ExportFileStore guarda los archivos de las exportaciones asíncronas.
+type ExportFileStore interface {
+	Create(ctx context.Context, name string) (io.WriteCloser, error)
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	Delete(ctx context.Context, name string) error
+	// DeleteOlderThan borra los archivos sin modificar desde before, incluidos
+	// los que quedaron sin trabajo tras una caída, y devuelve cuántos borró.
+	DeleteOlderThan(ctx context.Context, before time.Time) (int, error)
+}
@@ -3,10 +3,43 @@ package repositories
 import (
 	"context"
 	models "license-service/internal/domain/model"
+	"time"
 )
 
+// LicenseCriteria filtra los listados de licencias; los campos vacíos no filtran.
+type LicenseCriteria struct {
+	PatientID   string
+	Type        string
+	Status      string
+	EmployerRut string
+	// Acknowledged filtra por la recepción de EmployerRut; nil no filtra.
+	Acknowledged *bool
+}
+
 type LicenseRepository interface {
 	Save(ctx context.Context, license *models.License) error
+	// SaveBatch guarda varias licencias nuevas, cada una con su versión 1, en
+	// una sola transacción: o quedan todas o ninguna.
+	SaveBatch(ctx context.Context, licenses []*models.License) error
 	FindByFolio(ctx context.Context, folio string) (*models.License, error)
-	FindByPatientID(ctx context.Context, patientID string) ([]*models.License, error)
+	// FindByFolios resuelve varios folios en una sola consulta; los que no
+	// existen simplemente no aparecen y el orden no está garantizado.
+	FindByFolios(ctx context.Context, folios []string) ([]*models.License, error)
+	FindByCriteria(ctx context.Context, criteria LicenseCriteria) ([]*models.License, error)
+	// ScanByCriteria recorre las licencias de criteria en orden de creación y
+	// entrega a fn páginas de hasta pageSize, sin cargarlas todas en memoria.
+	// Si fn falla el recorrido se detiene con ese error.
+	ScanByCriteria(ctx context.Context, criteria LicenseCriteria, pageSize int, fn func([]*models.License) error) error
+	FindIssuedEndingBefore(ctx context.Context, date time.Time) ([]*models.License, error)
+	// Las escrituras sobre una licencia existente exigen que siga en
+	// license.Revision, la avanzan y fallan con ErrOptimisticLockFailed si otra
+	// escritura se adelantó.
+	UpdateStatus(ctx context.Context, license *models.License) error
+	UpdateEmployer(ctx context.Context, license *models.License, employer models.Employer) error
+	UpdateAdjudication(ctx context.Context, license *models.License) error
+	// Save guarda también la versión 1 de la licencia; SaveAmendment guarda una
+	// enmienda junto con sus versiones.
+	SaveAmendment(ctx context.Context, license *models.License, versions []*models.LicenseVersion) error
+	FindVersions(ctx context.Context, folio string) ([]*models.LicenseVersion, error)
+	FindVersion(ctx context.Context, folio string, version int) (*models.LicenseVersion, error)
 }
@@ -14,7 +14,7 @@ func NewDoctorID(value string) (*DoctorID, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &DoctorID{}, nil
+	return &DoctorID{value: value}, nil
 }
 
 func validateDoctorID(value string) error {
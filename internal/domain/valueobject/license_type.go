@@ -0,0 +1,102 @@
+package valueobject
+
+import (
+	"sort"
+
+	errors "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+)
+
+const (
+	LicenseTypeCommonIllness       = "common_illness"
+	LicenseTypeMaternity           = "maternity"
+	LicenseTypeWorkAccident        = "work_accident"
+	LicenseTypeOccupationalDisease = "occupational_disease"
+	LicenseTypeSickChild           = "sick_child"
+)
+
+// Quién paga el subsidio: la Isapre/Fonasa (vía COMPIN) para enfermedad y
+// maternidad, o la mutual de seguridad (Ley 16.744) para accidentes y
+// enfermedades profesionales.
+const (
+	PayerHealthInsurer = "health_insurer"
+	PayerMutual        = "mutual"
+)
+
+// MaternityPrenatalDays es el descanso prenatal: la licencia de maternidad puede
+// empezar como máximo esta cantidad de días antes de la fecha probable de parto.
+const MaternityPrenatalDays = 42
+
+type licenseTypeRules struct {
+	maxDays          uint8
+	payer            string
+	requiresDueDate  bool
+	requiresChildRut bool
+}
+
+var licenseTypes = map[string]licenseTypeRules{
+	LicenseTypeCommonIllness:       {maxDays: 30, payer: PayerHealthInsurer},
+	LicenseTypeMaternity:           {maxDays: 126, payer: PayerHealthInsurer, requiresDueDate: true},
+	LicenseTypeWorkAccident:        {maxDays: 30, payer: PayerMutual},
+	LicenseTypeOccupationalDisease: {maxDays: 30, payer: PayerMutual},
+	LicenseTypeSickChild:           {maxDays: 30, payer: PayerHealthInsurer, requiresChildRut: true},
+}
+
+type LicenseType struct {
+	value string
+	rules licenseTypeRules
+}
+
+// NewLicenseType valida el tipo; vacío equivale a enfermedad común, que era el
+// único tipo antes de que existiera el campo.
+func NewLicenseType(value string) (*LicenseType, error) {
+	if value == "" {
+		value = LicenseTypeCommonIllness
+	}
+
+	rules, ok := licenseTypes[value]
+	if !ok {
+		appError := errors.NewAppError(
+			errors.ErrInvalidData,
+			"LicenseType",
+			"NewLicenseType",
+			"unknown license type: "+value)
+		logger.Error("LicenseType", "NewLicenseType", appError, "type", value, "allowed", LicenseTypes())
+		return nil, appError
+	}
+	return &LicenseType{value: value, rules: rules}, nil
+}
+
+// LicenseTypes lista los tipos válidos en orden alfabético.
+func LicenseTypes() []string {
+	types := make([]string, 0, len(licenseTypes))
+	for licenseType := range licenseTypes {
+		types = append(types, licenseType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func (licenseType LicenseType) MaxDays() uint8 {
+	return licenseType.rules.maxDays
+}
+
+func (licenseType LicenseType) Payer() string {
+	return licenseType.rules.payer
+}
+
+func (licenseType LicenseType) RequiresDueDate() bool {
+	return licenseType.rules.requiresDueDate
+}
+
+func (licenseType LicenseType) RequiresChildRut() bool {
+	return licenseType.rules.requiresChildRut
+}
+
+func (licenseType LicenseType) Value() string {
+	return licenseType.value
+}
+
+func (licenseType LicenseType) String() string {
+	return licenseType.value
+}
@@ -0,0 +1,101 @@
+package valueobject
+
+import (
+	"strings"
+
+	errors "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+)
+
+const (
+	RestTypeTotal   = "total"
+	RestTypePartial = "partial"
+)
+
+const (
+	RestLocationHome     = "home"
+	RestLocationHospital = "hospital"
+	RestLocationOther    = "other"
+)
+
+// RestType indica si el reposo es de jornada completa o de media jornada.
+type RestType struct {
+	value string
+}
+
+// NewRestType valida el tipo de reposo; vacío equivale a reposo total.
+func NewRestType(value string) (*RestType, error) {
+	switch value {
+	case "":
+		return &RestType{value: RestTypeTotal}, nil
+	case RestTypeTotal, RestTypePartial:
+		return &RestType{value: value}, nil
+	}
+
+	appError := errors.NewAppError(
+		errors.ErrInvalidData,
+		"RestType",
+		"NewRestType",
+		"restType must be total or partial")
+	logger.Error("RestType", "NewRestType", appError, "restType", value)
+	return nil, appError
+}
+
+// DayFactor es la fracción de jornada que cubre cada día de reposo.
+func (restType RestType) DayFactor() float64 {
+	if restType.value == RestTypePartial {
+		return 0.5
+	}
+	return 1
+}
+
+func (restType RestType) Value() string {
+	return restType.value
+}
+
+func (restType RestType) String() string {
+	return restType.value
+}
+
+// RestLocation es el lugar donde se cumple el reposo. La dirección solo se
+// registra, y es obligatoria, cuando el lugar es "other".
+type RestLocation struct {
+	value   string
+	address string
+}
+
+// NewRestLocation valida el lugar de reposo; vacío equivale al domicilio.
+func NewRestLocation(value, address string) (*RestLocation, error) {
+	address = strings.TrimSpace(address)
+	if value == "" {
+		value = RestLocationHome
+	}
+
+	var appError *errors.AppError
+	switch {
+	case value != RestLocationHome && value != RestLocationHospital && value != RestLocationOther:
+		appError = errors.NewAppError(errors.ErrInvalidData, "RestLocation", "NewRestLocation", "restLocation must be home, hospital or other")
+	case value == RestLocationOther && address == "":
+		appError = errors.NewAppError(errors.ErrMissingRequiredField, "RestLocation", "NewRestLocation", "restAddress is required when restLocation is other")
+	case value != RestLocationOther && address != "":
+		appError = errors.NewAppError(errors.ErrInvalidData, "RestLocation", "NewRestLocation", "restAddress only applies when restLocation is other")
+	}
+	if appError != nil {
+		logger.Error("RestLocation", "NewRestLocation", appError, "restLocation", value)
+		return nil, appError
+	}
+
+	return &RestLocation{value: value, address: address}, nil
+}
+
+func (location RestLocation) Value() string {
+	return location.value
+}
+
+func (location RestLocation) Address() string {
+	return location.address
+}
+
+func (location RestLocation) String() string {
+	return location.value
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	entities "license-service/internal/persistence/entities"
+	"license-service/pkg/health"
+	appError "license-service/pkg/log/error"
+)
+
+func NewDatabaseChecker(db *gorm.DB) health.Checker {
+	return health.NewChecker("postgres", func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return appError.WrapError(appError.ErrHealthCheckFailed, "Database", "Ping", "Failed to get underlying sql.DB", err)
+		}
+
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return appError.WrapError(appError.ErrHealthCheckFailed, "Database", "Ping", "Database ping failed", err)
+		}
+		return nil
+	})
+}
+
+func NewLicensesTableChecker(db *gorm.DB) health.Checker {
+	return health.NewChecker("postgres.licenses_table", func(ctx context.Context) error {
+		if !db.WithContext(ctx).Migrator().HasTable(&entities.LicenseEntity{}) {
+			return appError.NewAppError(appError.ErrHealthCheckFailed, "Database", "HasTable", "licenses table does not exist")
+		}
+		return nil
+	})
+}
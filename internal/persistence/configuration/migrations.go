@@ -0,0 +1,34 @@
+package database
+
+import (
+	"gorm.io/gorm"
+
+	entities "license-service/internal/persistence/entities"
+	appError "license-service/pkg/log/error"
+	appLogger "license-service/pkg/log/logger"
+)
+
+func RunMigrations(db *gorm.DB) error {
+	log := appLogger.NewLogger()
+
+	err := db.AutoMigrate(
+		&entities.LicenseEntity{},
+		&entities.LicenseEmployerEntity{},
+		&entities.LicenseVersionEntity{},
+		&entities.APIKeyEntity{},
+		&entities.ExportJobEntity{},
+	)
+	if err != nil {
+		migrationError := appError.WrapError(
+			appError.ErrDBMigration,
+			"Database",
+			"RunMigrations",
+			"Failed to migrate database schema",
+			err)
+		log.Error("Database", "RunMigrations", migrationError, "error: "+err.Error())
+		return migrationError
+	}
+
+	log.Info("Database", "RunMigrations", "Database schema is up to date")
+	return nil
+}
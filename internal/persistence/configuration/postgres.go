@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 
 	"gorm.io/driver/postgres"
@@ -10,10 +11,10 @@ import (
 	envConfig "license-service/pkg/env"
 	appError "license-service/pkg/log/error"
 	appLogger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
 )
 
-func NewConnection() (*gorm.DB, error) {
-	config := envConfig.Load()
+func NewConnection(config *envConfig.Config) (*gorm.DB, error) {
 	log := appLogger.NewLogger()
 
 	var dsn string
@@ -43,12 +44,18 @@ func NewConnection() (*gorm.DB, error) {
 		logger.Config{
 			SlowThreshold: config.Database.SlowThreshold,
 			LogLevel:      gormLogLevel,
-			Colorful:      true,
+			Colorful:      config.App.LogFormat != appLogger.FormatJSON,
 		},
 	)
 
+	// Abrir sin ping: la conexión real se establece con Ping o en la primera
+	// consulta, y database/sql reconecta solo cuando la base vuelve.
+	// TranslateError convierte las violaciones de restricciones en los errores
+	// de gorm (gorm.ErrDuplicatedKey) que revisan los repositorios.
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: dbLogger,
+		Logger:               dbLogger,
+		DisableAutomaticPing: true,
+		TranslateError:       true,
 	})
 
 	if err != nil {
@@ -61,12 +68,23 @@ func NewConnection() (*gorm.DB, error) {
 		return nil, dbError
 	}
 
+	if err := registerTracingCallbacks(db); err != nil {
+		tracingError := appError.WrapError(
+			appError.ErrServiceInit,
+			"Database",
+			"NewConnection",
+			"Failed to register tracing callbacks",
+			err)
+		log.Error("Database", "NewConnection", tracingError)
+		return nil, tracingError
+	}
+
 	// Configurar pool de conexiones
 	if err := configureConnectionPool(db, config); err != nil {
 		return nil, err
 	}
 
-	log.Info("Database", "NewConnection", "Database connection established successfully")
+	log.Info("Database", "NewConnection", "Database connection pool created")
 	return db, nil
 }
 
@@ -88,12 +106,46 @@ func configureConnectionPool(db *gorm.DB, config *envConfig.Config) error {
 	sqlDB.SetMaxOpenConns(config.Database.MaxOpenConns)
 	sqlDB.SetConnMaxLifetime(config.Database.ConnMaxLifetime)
 
+	if err := metrics.RegisterDBStats(sqlDB, config.Database.Name); err != nil {
+		log.Error("Database", "configureConnectionPool", err, "failed to register connection pool metrics")
+	}
+
 	log.Info("Database", "configureConnectionPool", fmt.Sprintf("Connection pool configured - MaxIdle: %d, MaxOpen: %d, MaxLifetime: %v",
 		config.Database.MaxIdleConns, config.Database.MaxOpenConns, config.Database.ConnMaxLifetime))
 
 	return nil
 }
 
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return appError.WrapError(appError.ErrDBConnection, "Database", "Ping", "Failed to get underlying sql.DB", err)
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return appError.WrapError(appError.ErrDBConnection, "Database", "Ping", "Database ping failed", err)
+	}
+	return nil
+}
+
+func Close(db *gorm.DB) error {
+	log := appLogger.NewLogger()
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return appError.WrapError(appError.ErrDBConnection, "Database", "Close", "Failed to get underlying sql.DB", err)
+	}
+
+	if err := sqlDB.Close(); err != nil {
+		closeError := appError.WrapError(appError.ErrDBConnection, "Database", "Close", "Failed to close connection pool", err)
+		log.Error("Database", "Close", closeError, "error: "+err.Error())
+		return closeError
+	}
+
+	log.Info("Database", "Close", "Database connection pool closed")
+	return nil
+}
+
 func getGormLogLevel(level string) logger.LogLevel {
 	switch level {
 	case "silent":
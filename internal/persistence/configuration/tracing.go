@@ -0,0 +1,92 @@
+package database
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"license-service/pkg/telemetry"
+)
+
+const spanInstanceKey = "telemetry:span"
+
+// registerTracingCallbacks abre un span hijo del contexto de la consulta
+// (db.WithContext(ctx)) alrededor de cada operación de GORM.
+func registerTracingCallbacks(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	if err := callbacks.Create().Before("gorm:create").Register("telemetry:before_create", startSpan("gorm.Create")); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("telemetry:after_create", endSpan); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("telemetry:before_query", startSpan("gorm.Query")); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("gorm:query").Register("telemetry:after_query", endSpan); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("telemetry:before_update", startSpan("gorm.Update")); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("telemetry:after_update", endSpan); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("telemetry:before_delete", startSpan("gorm.Delete")); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("telemetry:after_delete", endSpan); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("telemetry:before_row", startSpan("gorm.Row")); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("gorm:row").Register("telemetry:after_row", endSpan); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("telemetry:before_raw", startSpan("gorm.Raw")); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("telemetry:after_raw", endSpan)
+}
+
+func startSpan(name string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement == nil || db.Statement.Context == nil {
+			return
+		}
+
+		ctx, span := telemetry.StartSpan(db.Statement.Context, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String("db.system", "postgresql")),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(spanInstanceKey, span)
+	}
+}
+
+func endSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanInstanceKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("db.sql.table", db.Statement.Table),
+		attribute.String("db.statement", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	telemetry.EndSpan(span, err)
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	domain "license-service/internal/domain/model"
+	"strings"
+	"time"
+)
+
+type APIKeyEntity struct {
+	ID         uint       `gorm:"primarykey"`
+	Prefix     string     `gorm:"uniqueIndex;not null;size:32"`
+	Hash       string     `gorm:"not null;size:64"`
+	Name       string     `gorm:"not null;size:100"`
+	Role       string     `gorm:"not null;size:20"`
+	OwnerRut   string     `gorm:"size:50;column:owner_rut"`
+	Scopes     string     `gorm:"not null;type:text"`
+	ExpiresAt  *time.Time `gorm:"column:expires_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at"`
+	UsageCount int64      `gorm:"not null;default:0;column:usage_count"`
+	CreatedAt  time.Time  `gorm:"default:now()"`
+}
+
+func (APIKeyEntity) TableName() string {
+	return "api_keys"
+}
+
+func (e *APIKeyEntity) ToDomain() *domain.APIKey {
+	var scopes []string
+	if e.Scopes != "" {
+		scopes = strings.Split(e.Scopes, ",")
+	}
+
+	return &domain.APIKey{
+		Prefix:     e.Prefix,
+		Hash:       e.Hash,
+		Name:       e.Name,
+		Role:       e.Role,
+		OwnerRut:   e.OwnerRut,
+		Scopes:     scopes,
+		ExpiresAt:  e.ExpiresAt,
+		RevokedAt:  e.RevokedAt,
+		CreatedAt:  e.CreatedAt,
+		LastUsedAt: e.LastUsedAt,
+		UsageCount: e.UsageCount,
+	}
+}
+
+func APIKeyFromDomain(key *domain.APIKey) *APIKeyEntity {
+	createdAt := key.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	return &APIKeyEntity{
+		Prefix:     key.Prefix,
+		Hash:       key.Hash,
+		Name:       key.Name,
+		Role:       key.Role,
+		OwnerRut:   key.OwnerRut,
+		Scopes:     strings.Join(key.Scopes, ","),
+		ExpiresAt:  key.ExpiresAt,
+		RevokedAt:  key.RevokedAt,
+		LastUsedAt: key.LastUsedAt,
+		UsageCount: key.UsageCount,
+		CreatedAt:  createdAt,
+	}
+}
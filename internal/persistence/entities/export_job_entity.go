@@ -0,0 +1,55 @@
+package models
+
+import (
+	domain "license-service/internal/domain/model"
+	"time"
+)
+
+// ExportJobEntity guarda el estado de una exportación asíncrona para que todas
+// las réplicas lo vean y sobreviva a un reinicio.
+type ExportJobEntity struct {
+	ID           string     `gorm:"primaryKey;size:32"`
+	Format       string     `gorm:"not null;size:10"`
+	RequestedBy  string     `gorm:"not null;default:'';size:255;column:requested_by"`
+	Status       string     `gorm:"not null;size:20"`
+	Rows         int        `gorm:"not null;default:0"`
+	ErrorCode    string     `gorm:"size:50;column:error_code"`
+	ErrorMessage string     `gorm:"type:text;column:error_message"`
+	CreatedAt    time.Time  `gorm:"not null;index:idx_export_jobs_created_at"`
+	FinishedAt   *time.Time `gorm:"column:finished_at"`
+	ExpiresAt    *time.Time `gorm:"index:idx_export_jobs_expires_at;column:expires_at"`
+}
+
+func (ExportJobEntity) TableName() string {
+	return "export_jobs"
+}
+
+func (e *ExportJobEntity) ToDomain() *domain.ExportJob {
+	return &domain.ExportJob{
+		ID:           e.ID,
+		Format:       e.Format,
+		RequestedBy:  e.RequestedBy,
+		Status:       e.Status,
+		Rows:         e.Rows,
+		ErrorCode:    e.ErrorCode,
+		ErrorMessage: e.ErrorMessage,
+		CreatedAt:    e.CreatedAt,
+		FinishedAt:   e.FinishedAt,
+		ExpiresAt:    e.ExpiresAt,
+	}
+}
+
+func ExportJobFromDomain(job *domain.ExportJob) *ExportJobEntity {
+	return &ExportJobEntity{
+		ID:           job.ID,
+		Format:       job.Format,
+		RequestedBy:  job.RequestedBy,
+		Status:       job.Status,
+		Rows:         job.Rows,
+		ErrorCode:    job.ErrorCode,
+		ErrorMessage: job.ErrorMessage,
+		CreatedAt:    job.CreatedAt,
+		FinishedAt:   job.FinishedAt,
+		ExpiresAt:    job.ExpiresAt,
+	}
+}
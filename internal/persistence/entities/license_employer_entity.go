@@ -0,0 +1,41 @@
+package models
+
+import (
+	domain "license-service/internal/domain/model"
+	"time"
+)
+
+// LicenseEmployerEntity asocia una licencia con cada empleador al que se entrega.
+type LicenseEmployerEntity struct {
+	ID             uint       `gorm:"primarykey"`
+	LicenseID      uint       `gorm:"not null;uniqueIndex:idx_license_employers_license_employer;column:license_id"`
+	EmployerRut    string     `gorm:"not null;size:12;uniqueIndex:idx_license_employers_license_employer;index:idx_license_employers_employer_rut;column:employer_rut"`
+	AcknowledgedAt *time.Time `gorm:"column:acknowledged_at"`
+	CreatedAt      time.Time  `gorm:"default:now()"`
+}
+
+func (LicenseEmployerEntity) TableName() string {
+	return "license_employers"
+}
+
+func (e *LicenseEmployerEntity) ToDomain() domain.Employer {
+	return domain.Employer{
+		Rut:            e.EmployerRut,
+		AcknowledgedAt: e.AcknowledgedAt,
+	}
+}
+
+func LicenseEmployersFromDomain(employers []domain.Employer) []LicenseEmployerEntity {
+	if len(employers) == 0 {
+		return nil
+	}
+
+	entities := make([]LicenseEmployerEntity, 0, len(employers))
+	for _, employer := range employers {
+		entities = append(entities, LicenseEmployerEntity{
+			EmployerRut:    employer.Rut,
+			AcknowledgedAt: employer.AcknowledgedAt,
+		})
+	}
+	return entities
+}
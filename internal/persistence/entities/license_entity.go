@@ -6,15 +6,34 @@ import (
 )
 
 type LicenseEntity struct {
-	ID        uint      `gorm:"primarykey"`
-	Folio     string    `gorm:"uniqueIndex;not null;size:50"`
-	PatientID string    `gorm:"not null;size:50;index:idx_licenses_patient_id;column:patient_id"`
-	DoctorID  string    `gorm:"not null;size:50;column:doctor_id"`
-	Diagnosis string    `gorm:"not null;type:text"`
-	StartDate time.Time `gorm:"not null;type:date;column:start_date"`
-	Days      int       `gorm:"not null;check:days > 0"`
-	Status    string    `gorm:"not null;default:'issued';size:20"`
-	CreatedAt time.Time `gorm:"default:now()"`
+	ID        uint       `gorm:"primarykey"`
+	Folio     string     `gorm:"uniqueIndex;not null;size:50"`
+	PatientID string     `gorm:"not null;size:50;index:idx_licenses_patient_id;column:patient_id"`
+	DoctorID  string     `gorm:"not null;size:50;column:doctor_id"`
+	Diagnosis string     `gorm:"not null;type:text"`
+	StartDate time.Time  `gorm:"not null;type:date;column:start_date"`
+	Days      int        `gorm:"not null;check:days > 0"`
+	Status    string     `gorm:"not null;default:'issued';size:20"`
+	Type      string     `gorm:"not null;default:'common_illness';size:30;index:idx_licenses_type"`
+	Payer     string     `gorm:"not null;default:'health_insurer';size:30"`
+	DueDate   *time.Time `gorm:"type:date;column:due_date"`
+	ChildRut  string     `gorm:"size:12;column:child_rut"`
+
+	RestType     string    `gorm:"not null;default:'total';size:10;column:rest_type"`
+	RestLocation string    `gorm:"not null;default:'home';size:10;column:rest_location"`
+	RestAddress  string    `gorm:"type:text;column:rest_address"`
+	CreatedAt    time.Time `gorm:"default:now()"`
+
+	InsurerRut         string     `gorm:"size:12;index:idx_licenses_insurer_rut;column:insurer_rut"`
+	AdjudicationStatus string     `gorm:"not null;default:'under_review';size:20;index:idx_licenses_adjudication_status;column:adjudication_status"`
+	ResolutionReason   string     `gorm:"type:text;column:resolution_reason"`
+	ApprovedDays       *int       `gorm:"column:approved_days"`
+	ResolvedAt         *time.Time `gorm:"column:resolved_at"`
+	ResolvedBy         string     `gorm:"size:100;column:resolved_by"`
+	Version            int        `gorm:"not null;default:1"`
+	Revision           int        `gorm:"not null;default:1"`
+
+	Employers []LicenseEmployerEntity `gorm:"foreignKey:LicenseID;constraint:OnDelete:CASCADE"`
 }
 
 func (LicenseEntity) TableName() string {
@@ -30,11 +49,56 @@ func (e *LicenseEntity) ToDomain() *domain.License {
 		StartDate: e.StartDate,
 		Days:      uint8(e.Days),
 		Status:    e.Status,
+		IssuedAt:  e.CreatedAt,
+		Type:      e.Type,
+		Payer:     e.Payer,
+		DueDate:   e.DueDate,
+		ChildRut:  e.ChildRut,
+
+		RestType:     e.RestType,
+		RestLocation: e.RestLocation,
+		RestAddress:  e.RestAddress,
+		Employers:    employersToDomain(e.Employers),
+		InsurerRut:   e.InsurerRut,
+		Adjudication: e.adjudicationToDomain(),
+		Version:      e.Version,
+		Revision:     e.Revision,
+	}
+}
+
+func (e *LicenseEntity) adjudicationToDomain() domain.Adjudication {
+	adjudication := domain.Adjudication{
+		Status:     e.AdjudicationStatus,
+		Reason:     e.ResolutionReason,
+		ResolvedAt: e.ResolvedAt,
+		ResolvedBy: e.ResolvedBy,
+	}
+	if e.ApprovedDays != nil {
+		approvedDays := uint8(*e.ApprovedDays)
+		adjudication.ApprovedDays = &approvedDays
 	}
+	return adjudication
+}
+
+func employersToDomain(entities []LicenseEmployerEntity) []domain.Employer {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	employers := make([]domain.Employer, 0, len(entities))
+	for _, entity := range entities {
+		employers = append(employers, entity.ToDomain())
+	}
+	return employers
 }
 
 func FromDomain(license *domain.License) *LicenseEntity {
-	return &LicenseEntity{
+	createdAt := license.IssuedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	entity := &LicenseEntity{
 		Folio:     license.Folio,
 		PatientID: license.PatientID,
 		DoctorID:  license.DoctorID,
@@ -42,7 +106,33 @@ func FromDomain(license *domain.License) *LicenseEntity {
 		StartDate: license.StartDate,
 		Days:      int(license.Days),
 		Status:    string(license.Status),
-		CreatedAt: time.Now(),
+		Type:      license.Type,
+		Payer:     license.Payer,
+		DueDate:   license.DueDate,
+		ChildRut:  license.ChildRut,
+		CreatedAt: createdAt,
+
+		RestType:     license.RestType,
+		RestLocation: license.RestLocation,
+		RestAddress:  license.RestAddress,
+		Employers:    LicenseEmployersFromDomain(license.Employers),
+		Version:      license.CurrentVersion(),
+		Revision:     license.CurrentRevision(),
+	}
+	entity.setAdjudication(license)
+	return entity
+}
+
+func (e *LicenseEntity) setAdjudication(license *domain.License) {
+	e.InsurerRut = license.InsurerRut
+	e.AdjudicationStatus = license.Adjudication.Status
+	e.ResolutionReason = license.Adjudication.Reason
+	e.ResolvedAt = license.Adjudication.ResolvedAt
+	e.ResolvedBy = license.Adjudication.ResolvedBy
+	e.ApprovedDays = nil
+	if license.Adjudication.ApprovedDays != nil {
+		approvedDays := int(*license.Adjudication.ApprovedDays)
+		e.ApprovedDays = &approvedDays
 	}
 }
 
@@ -54,4 +144,14 @@ func (e *LicenseEntity) UpdateFromDomain(license *domain.License) {
 	e.StartDate = license.StartDate
 	e.Days = int(license.Days)
 	e.Status = string(license.Status)
+	e.Type = license.Type
+	e.Payer = license.Payer
+	e.DueDate = license.DueDate
+	e.ChildRut = license.ChildRut
+	e.RestType = license.RestType
+	e.RestLocation = license.RestLocation
+	e.RestAddress = license.RestAddress
+	e.Version = license.CurrentVersion()
+	e.Revision = license.CurrentRevision()
+	e.setAdjudication(license)
 }
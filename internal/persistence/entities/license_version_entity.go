@@ -0,0 +1,88 @@
+package models
+
+import (
+	"encoding/json"
+	domain "license-service/internal/domain/model"
+	"time"
+)
+
+// LicenseVersionEntity guarda cada versión de una licencia. Las filas solo se
+// insertan: nunca se actualizan ni se borran.
+type LicenseVersionEntity struct {
+	ID      uint   `gorm:"primarykey"`
+	Folio   string `gorm:"not null;size:50;uniqueIndex:idx_license_versions_folio_version"`
+	Version int    `gorm:"not null;uniqueIndex:idx_license_versions_folio_version"`
+	Author  string `gorm:"not null;size:100"`
+	Reason  string `gorm:"not null;type:text"`
+	// Changes y Snapshot se guardan como JSON: la lista de campos modificados y
+	// la licencia completa en esa versión.
+	Changes   string    `gorm:"not null;type:jsonb"`
+	Snapshot  string    `gorm:"not null;type:jsonb"`
+	CreatedAt time.Time `gorm:"default:now()"`
+}
+
+func (LicenseVersionEntity) TableName() string {
+	return "license_versions"
+}
+
+type fieldChangeRecord struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+func LicenseVersionFromDomain(version *domain.LicenseVersion) (*LicenseVersionEntity, error) {
+	changes := make([]fieldChangeRecord, 0, len(version.Changes))
+	for _, change := range version.Changes {
+		changes = append(changes, fieldChangeRecord(change))
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	snapshotJSON, err := json.Marshal(version.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := version.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	return &LicenseVersionEntity{
+		Folio:     version.Folio,
+		Version:   version.Version,
+		Author:    version.Author,
+		Reason:    version.Reason,
+		Changes:   string(changesJSON),
+		Snapshot:  string(snapshotJSON),
+		CreatedAt: createdAt,
+	}, nil
+}
+
+func (e *LicenseVersionEntity) ToDomain() (*domain.LicenseVersion, error) {
+	var changes []fieldChangeRecord
+	if err := json.Unmarshal([]byte(e.Changes), &changes); err != nil {
+		return nil, err
+	}
+
+	var snapshot domain.License
+	if err := json.Unmarshal([]byte(e.Snapshot), &snapshot); err != nil {
+		return nil, err
+	}
+
+	version := &domain.LicenseVersion{
+		Folio:     e.Folio,
+		Version:   e.Version,
+		Author:    e.Author,
+		Reason:    e.Reason,
+		Snapshot:  snapshot,
+		CreatedAt: e.CreatedAt,
+	}
+	for _, change := range changes {
+		version.Changes = append(version.Changes, domain.FieldChange(change))
+	}
+	return version, nil
+}
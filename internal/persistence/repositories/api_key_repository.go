@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domain "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	entities "license-service/internal/persistence/entities"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+
+	"gorm.io/gorm"
+)
+
+type apiKeyRepositoryImpl struct {
+	db           *gorm.DB
+	queryTimeout time.Duration
+}
+
+func NewAPIKeyRepositoryImpl(db *gorm.DB, queryTimeout time.Duration) repositories.APIKeyRepository {
+	return &apiKeyRepositoryImpl{
+		db:           db,
+		queryTimeout: queryTimeout,
+	}
+}
+
+func (r *apiKeyRepositoryImpl) Save(ctx context.Context, key *domain.APIKey) error {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	logger.FromContext(ctx).Info("APIKeyRepository", "Save", "attempting to save api key with prefix: "+key.Prefix)
+
+	entity := entities.APIKeyFromDomain(key)
+
+	result := r.db.WithContext(ctx).Create(entity)
+	if result.Error != nil {
+		var appErr *errorInfo.AppError
+
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			appErr = errorInfo.NewAppError(
+				errorInfo.ErrAlreadyExists,
+				"APIKeyRepository",
+				"Save",
+				"api key with this prefix already exists",
+			)
+		} else {
+			appErr = queryError("APIKeyRepository", "Save", "failed to save api key", result.Error)
+		}
+		logger.FromContext(ctx).Error("APIKeyRepository", "Save", appErr, "database insert failed")
+		return appErr
+	}
+
+	logger.FromContext(ctx).Info("APIKeyRepository", "Save", "api key saved successfully with prefix: "+key.Prefix)
+	return nil
+}
+
+func (r *apiKeyRepositoryImpl) FindByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	var entity entities.APIKeyEntity
+	result := r.db.WithContext(ctx).Where("prefix = ?", prefix).First(&entity)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			logger.FromContext(ctx).Info("APIKeyRepository", "FindByPrefix", "api key not found for prefix: "+prefix)
+			return nil, nil
+		}
+
+		appErr := queryError("APIKeyRepository", "FindByPrefix", "failed to find api key", result.Error)
+		logger.FromContext(ctx).Error("APIKeyRepository", "FindByPrefix", appErr, "database query failed")
+		return nil, appErr
+	}
+
+	return entity.ToDomain(), nil
+}
+
+func (r *apiKeyRepositoryImpl) FindAll(ctx context.Context) ([]*domain.APIKey, error) {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	var entities []entities.APIKeyEntity
+	result := r.db.WithContext(ctx).Order("created_at DESC").Find(&entities)
+
+	if result.Error != nil {
+		appErr := queryError("APIKeyRepository", "FindAll", "failed to query api keys", result.Error)
+		logger.FromContext(ctx).Error("APIKeyRepository", "FindAll", appErr, "database query failed")
+		return nil, appErr
+	}
+
+	keys := make([]*domain.APIKey, 0, len(entities))
+	for _, entity := range entities {
+		keys = append(keys, entity.ToDomain())
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepositoryImpl) Update(ctx context.Context, key *domain.APIKey) error {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	entity := entities.APIKeyFromDomain(key)
+
+	result := r.db.WithContext(ctx).
+		Model(&entities.APIKeyEntity{}).
+		Where("prefix = ?", key.Prefix).
+		Updates(map[string]interface{}{
+			"name":       entity.Name,
+			"role":       entity.Role,
+			"owner_rut":  entity.OwnerRut,
+			"scopes":     entity.Scopes,
+			"expires_at": entity.ExpiresAt,
+			"revoked_at": entity.RevokedAt,
+		})
+
+	if result.Error != nil {
+		appErr := queryError("APIKeyRepository", "Update", "failed to update api key", result.Error)
+		logger.FromContext(ctx).Error("APIKeyRepository", "Update", appErr, "database update failed")
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		return errorInfo.NewAppError(
+			errorInfo.ErrNotFound,
+			"APIKeyRepository",
+			"Update",
+			"api key not found",
+		)
+	}
+
+	logger.FromContext(ctx).Info("APIKeyRepository", "Update", "api key updated successfully with prefix: "+key.Prefix)
+	return nil
+}
+
+func (r *apiKeyRepositoryImpl) RecordUsage(ctx context.Context, prefix string, usedAt time.Time) error {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Model(&entities.APIKeyEntity{}).
+		Where("prefix = ?", prefix).
+		Updates(map[string]interface{}{
+			"usage_count":  gorm.Expr("usage_count + 1"),
+			"last_used_at": usedAt,
+		})
+
+	if result.Error != nil {
+		appErr := queryError("APIKeyRepository", "RecordUsage", "failed to record api key usage", result.Error)
+		logger.FromContext(ctx).Error("APIKeyRepository", "RecordUsage", appErr, "database update failed")
+		return appErr
+	}
+	return nil
+}
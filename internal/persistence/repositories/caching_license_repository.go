@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	domain "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	"license-service/pkg/cache"
+	logger "license-service/pkg/log/logger"
+	"license-service/pkg/metrics"
+)
+
+const licenseCacheName = "licenses"
+
+// notFoundMarker se guarda para los folios inexistentes (caché negativa).
+var notFoundMarker = []byte("null")
+
+// cachingLicenseRepository sirve FindByFolio desde la caché y la invalida en
+// cada escritura. El resto de las consultas van directo al repositorio.
+type cachingLicenseRepository struct {
+	next        repositories.LicenseRepository
+	backend     *cache.Guard
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+func NewCachingLicenseRepository(next repositories.LicenseRepository, backend *cache.Guard, ttl, negativeTTL time.Duration) repositories.LicenseRepository {
+	return &cachingLicenseRepository{
+		next:        next,
+		backend:     backend,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+	}
+}
+
+func licenseCacheKey(folio string) string {
+	return "license:folio:" + folio
+}
+
+func (r *cachingLicenseRepository) FindByFolio(ctx context.Context, folio string) (*domain.License, error) {
+	key := licenseCacheKey(folio)
+
+	value, found, err := r.backend.Get(ctx, key)
+	switch {
+	case err != nil:
+		metrics.CacheRequestsTotal.WithLabelValues(licenseCacheName, "error").Inc()
+		logger.FromContext(ctx).Error("LicenseCache", "FindByFolio", err, "cache read failed, falling back to database")
+	case found && string(value) == string(notFoundMarker):
+		metrics.CacheRequestsTotal.WithLabelValues(licenseCacheName, "negative_hit").Inc()
+		return nil, nil
+	case found:
+		var license domain.License
+		if err := json.Unmarshal(value, &license); err == nil {
+			metrics.CacheRequestsTotal.WithLabelValues(licenseCacheName, "hit").Inc()
+			return &license, nil
+		}
+		metrics.CacheRequestsTotal.WithLabelValues(licenseCacheName, "error").Inc()
+	default:
+		metrics.CacheRequestsTotal.WithLabelValues(licenseCacheName, "miss").Inc()
+	}
+
+	// Si una escritura invalida el folio mientras se lee la base, lo leído puede
+	// ser anterior a ella y no se guarda.
+	ticket := r.backend.Begin(key)
+	defer r.backend.Release(ticket)
+
+	license, err := r.next.FindByFolio(ctx, folio)
+	if err != nil {
+		return nil, err
+	}
+
+	if license == nil {
+		if r.negativeTTL > 0 {
+			r.set(ctx, ticket, notFoundMarker, r.negativeTTL)
+		}
+		return nil, nil
+	}
+
+	if encoded, err := json.Marshal(license); err == nil {
+		r.set(ctx, ticket, encoded, r.ttl)
+	}
+	return license, nil
+}
+
+func (r *cachingLicenseRepository) Save(ctx context.Context, license *domain.License) error {
+	err := r.next.Save(ctx, license)
+	r.invalidate(ctx, license.Folio)
+	return err
+}
+
+// SaveBatch invalida todos los folios del lote para descartar las entradas
+// negativas de quien los consultó antes de la importación.
+func (r *cachingLicenseRepository) SaveBatch(ctx context.Context, licenses []*domain.License) error {
+	err := r.next.SaveBatch(ctx, licenses)
+	for _, license := range licenses {
+		r.invalidate(ctx, license.Folio)
+	}
+	return err
+}
+
+func (r *cachingLicenseRepository) UpdateStatus(ctx context.Context, license *domain.License) error {
+	err := r.next.UpdateStatus(ctx, license)
+	r.invalidate(ctx, license.Folio)
+	return err
+}
+
+func (r *cachingLicenseRepository) UpdateEmployer(ctx context.Context, license *domain.License, employer domain.Employer) error {
+	err := r.next.UpdateEmployer(ctx, license, employer)
+	r.invalidate(ctx, license.Folio)
+	return err
+}
+
+func (r *cachingLicenseRepository) UpdateAdjudication(ctx context.Context, license *domain.License) error {
+	err := r.next.UpdateAdjudication(ctx, license)
+	r.invalidate(ctx, license.Folio)
+	return err
+}
+
+func (r *cachingLicenseRepository) SaveAmendment(ctx context.Context, license *domain.License, versions []*domain.LicenseVersion) error {
+	err := r.next.SaveAmendment(ctx, license, versions)
+	r.invalidate(ctx, license.Folio)
+	return err
+}
+
+// Las versiones son inmutables pero poco consultadas; se leen siempre de la base.
+func (r *cachingLicenseRepository) FindVersions(ctx context.Context, folio string) ([]*domain.LicenseVersion, error) {
+	return r.next.FindVersions(ctx, folio)
+}
+
+func (r *cachingLicenseRepository) FindVersion(ctx context.Context, folio string, version int) (*domain.LicenseVersion, error) {
+	return r.next.FindVersion(ctx, folio, version)
+}
+
+// FindByFolios va directo a la base: un lote ya es una sola consulta y no
+// conviene llenar la caché con folios que se verifican una vez al mes.
+func (r *cachingLicenseRepository) FindByFolios(ctx context.Context, folios []string) ([]*domain.License, error) {
+	return r.next.FindByFolios(ctx, folios)
+}
+
+func (r *cachingLicenseRepository) FindByCriteria(ctx context.Context, criteria repositories.LicenseCriteria) ([]*domain.License, error) {
+	return r.next.FindByCriteria(ctx, criteria)
+}
+
+func (r *cachingLicenseRepository) ScanByCriteria(ctx context.Context, criteria repositories.LicenseCriteria, pageSize int, fn func([]*domain.License) error) error {
+	return r.next.ScanByCriteria(ctx, criteria, pageSize, fn)
+}
+
+func (r *cachingLicenseRepository) FindIssuedEndingBefore(ctx context.Context, date time.Time) ([]*domain.License, error) {
+	return r.next.FindIssuedEndingBefore(ctx, date)
+}
+
+func (r *cachingLicenseRepository) set(ctx context.Context, ticket cache.Ticket, value []byte, ttl time.Duration) {
+	if _, err := r.backend.SetIfCurrent(ctx, ticket, value, ttl); err != nil {
+		logger.FromContext(ctx).Error("LicenseCache", "set", err, "cache write failed")
+	}
+}
+
+// invalidate se ejecuta también cuando la escritura falla: si la base quedó en
+// un estado incierto es preferible releerla.
+func (r *cachingLicenseRepository) invalidate(ctx context.Context, folio string) {
+	if err := r.backend.Delete(ctx, licenseCacheKey(folio)); err != nil {
+		logger.FromContext(ctx).Error("LicenseCache", "invalidate", err, "cache invalidation failed for folio: "+folio)
+	}
+}
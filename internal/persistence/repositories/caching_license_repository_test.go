@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	domain "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	"license-service/pkg/cache"
+)
+
+// licenseStore es un repositorio en memoria. Si loading no es nil, cada
+// FindByFolio avisa por loading con lo leído y espera resume antes de
+// devolverlo, para intercalar una escritura en medio de la lectura.
+type licenseStore struct {
+	repositories.LicenseRepository
+
+	mu       sync.Mutex
+	licenses map[string]domain.License
+	reads    int
+	loading  chan struct{}
+	resume   chan struct{}
+}
+
+func (s *licenseStore) FindByFolio(_ context.Context, folio string) (*domain.License, error) {
+	s.mu.Lock()
+	s.reads++
+	license, ok := s.licenses[folio]
+	s.mu.Unlock()
+
+	if s.loading != nil {
+		s.loading <- struct{}{}
+		<-s.resume
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &license, nil
+}
+
+func (s *licenseStore) Save(_ context.Context, license *domain.License) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.licenses[license.Folio] = *license
+	return nil
+}
+
+func (s *licenseStore) UpdateStatus(ctx context.Context, license *domain.License) error {
+	return s.Save(ctx, license)
+}
+
+func newCachedStore(licenses ...domain.License) (*licenseStore, *cache.Guard, repositories.LicenseRepository) {
+	store := &licenseStore{licenses: make(map[string]domain.License)}
+	for _, license := range licenses {
+		store.licenses[license.Folio] = license
+	}
+	backend := cache.NewGuard(cache.NewLRU(0))
+	return store, backend, NewCachingLicenseRepository(store, backend, time.Hour, time.Hour)
+}
+
+func TestFindByFolioServesFromCache(t *testing.T) {
+	store, _, repository := newCachedStore(domain.License{Folio: "L-1", Status: "issued"})
+
+	for i := 0; i < 3; i++ {
+		license, err := repository.FindByFolio(context.Background(), "L-1")
+		if err != nil || license == nil || license.Status != "issued" {
+			t.Fatalf("FindByFolio = %+v, %v", license, err)
+		}
+	}
+	if store.reads != 1 {
+		t.Fatalf("database reads = %d, want 1", store.reads)
+	}
+}
+
+func TestWriteInvalidatesNegativeEntry(t *testing.T) {
+	_, _, repository := newCachedStore()
+	ctx := context.Background()
+
+	if license, _ := repository.FindByFolio(ctx, "L-1"); license != nil {
+		t.Fatalf("FindByFolio = %+v, want nil", license)
+	}
+	if err := repository.Save(ctx, &domain.License{Folio: "L-1", Status: "issued"}); err != nil {
+		t.Fatal(err)
+	}
+	if license, _ := repository.FindByFolio(ctx, "L-1"); license == nil {
+		t.Fatal("negative entry survived the write")
+	}
+}
+
+// Un lector se pierde la caché y lee la fila; antes de que la guarde, otra
+// solicitud la actualiza e invalida. El lector no debe dejar la fila vieja.
+func TestUpdateDuringLoadDoesNotCacheStaleRow(t *testing.T) {
+	store, _, repository := newCachedStore(domain.License{Folio: "L-1", Status: "issued"})
+	ctx := context.Background()
+	store.loading = make(chan struct{})
+	store.resume = make(chan struct{})
+
+	read := make(chan *domain.License)
+	go func() {
+		license, _ := repository.FindByFolio(ctx, "L-1")
+		read <- license
+	}()
+	<-store.loading
+
+	if err := repository.UpdateStatus(ctx, &domain.License{Folio: "L-1", Status: "cancelled"}); err != nil {
+		t.Fatal(err)
+	}
+	close(store.resume)
+	if license := <-read; license.Status != "issued" {
+		t.Fatalf("in-flight read = %q, want the row it read", license.Status)
+	}
+
+	store.loading = nil
+	license, err := repository.FindByFolio(ctx, "L-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if license.Status != "cancelled" {
+		t.Fatalf("FindByFolio after the update = %q, want cancelled", license.Status)
+	}
+}
+
+// El listener invalida por el mismo Guard: un aviso de otra réplica durante la
+// lectura también descarta lo leído.
+func TestNotificationDuringLoadDoesNotCacheStaleRow(t *testing.T) {
+	store, backend, repository := newCachedStore(domain.License{Folio: "L-1", Status: "issued"})
+	ctx := context.Background()
+	store.loading = make(chan struct{})
+	store.resume = make(chan struct{})
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = repository.FindByFolio(ctx, "L-1")
+		close(done)
+	}()
+	<-store.loading
+
+	store.licenses["L-1"] = domain.License{Folio: "L-1", Status: "cancelled"}
+	if err := backend.Clear(ctx); err != nil {
+		t.Fatal(err)
+	}
+	close(store.resume)
+	<-done
+
+	if _, found, _ := backend.Get(ctx, licenseCacheKey("L-1")); found {
+		t.Fatal("stale row cached after a Clear during the load")
+	}
+}
+
+func TestLoadWithoutInvalidationIsCached(t *testing.T) {
+	store, backend, repository := newCachedStore(domain.License{Folio: "L-1", Status: "issued"})
+	ctx := context.Background()
+	store.loading = make(chan struct{})
+	store.resume = make(chan struct{})
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = repository.FindByFolio(ctx, "L-1")
+		close(done)
+	}()
+	<-store.loading
+	// Invalidar otro folio no afecta la carga en curso.
+	if err := backend.Delete(ctx, licenseCacheKey("L-2")); err != nil {
+		t.Fatal(err)
+	}
+	close(store.resume)
+	<-done
+
+	if _, found, _ := backend.Get(ctx, licenseCacheKey("L-1")); !found {
+		t.Fatal("row not cached")
+	}
+}
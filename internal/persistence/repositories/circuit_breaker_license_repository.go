@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domain "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	"license-service/pkg/circuitbreaker"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+)
+
+// circuitBreakerLicenseRepository corta el acceso a la base tras varios fallos
+// de infraestructura seguidos: mientras el circuito está abierto cada llamada
+// falla de inmediato con ErrCircuitBreakerOpen en vez de esperar el timeout.
+type circuitBreakerLicenseRepository struct {
+	next    repositories.LicenseRepository
+	breaker *circuitbreaker.Breaker
+}
+
+func NewCircuitBreakerLicenseRepository(next repositories.LicenseRepository, breaker *circuitbreaker.Breaker) repositories.LicenseRepository {
+	return &circuitBreakerLicenseRepository{
+		next:    next,
+		breaker: breaker,
+	}
+}
+
+// IsInfrastructureFailure indica si un error del repositorio debe contar para el
+// circuit breaker. Solo cuentan los timeouts y la base inalcanzable: un error
+// causado por los datos de un cliente no debe cortar el servicio a los demás.
+func IsInfrastructureFailure(err error) bool {
+	var appErr *errorInfo.AppError
+	if !errors.As(err, &appErr) {
+		return false
+	}
+	return appErr.Code == errorInfo.ErrDBTimeout || appErr.Code == errorInfo.ErrDBConnection
+}
+
+func (r *circuitBreakerLicenseRepository) do(ctx context.Context, operation string, fn func() error) error {
+	err := r.breaker.Do(fn)
+	if errors.Is(err, circuitbreaker.ErrOpen) {
+		appErr := errorInfo.NewAppError(errorInfo.ErrCircuitBreakerOpen, "LicenseRepository", operation, "database temporarily unavailable")
+		logger.FromContext(ctx).Warn("LicenseRepository", operation, "circuit breaker open, failing fast")
+		return appErr
+	}
+	return err
+}
+
+func (r *circuitBreakerLicenseRepository) Save(ctx context.Context, license *domain.License) error {
+	return r.do(ctx, "Save", func() error {
+		return r.next.Save(ctx, license)
+	})
+}
+
+func (r *circuitBreakerLicenseRepository) SaveBatch(ctx context.Context, licenses []*domain.License) error {
+	return r.do(ctx, "SaveBatch", func() error {
+		return r.next.SaveBatch(ctx, licenses)
+	})
+}
+
+func (r *circuitBreakerLicenseRepository) FindByFolio(ctx context.Context, folio string) (license *domain.License, err error) {
+	err = r.do(ctx, "FindByFolio", func() error {
+		license, err = r.next.FindByFolio(ctx, folio)
+		return err
+	})
+	return license, err
+}
+
+func (r *circuitBreakerLicenseRepository) FindByFolios(ctx context.Context, folios []string) (licenses []*domain.License, err error) {
+	err = r.do(ctx, "FindByFolios", func() error {
+		licenses, err = r.next.FindByFolios(ctx, folios)
+		return err
+	})
+	return licenses, err
+}
+
+func (r *circuitBreakerLicenseRepository) FindByCriteria(ctx context.Context, criteria repositories.LicenseCriteria) (licenses []*domain.License, err error) {
+	err = r.do(ctx, "FindByCriteria", func() error {
+		licenses, err = r.next.FindByCriteria(ctx, criteria)
+		return err
+	})
+	return licenses, err
+}
+
+// ScanByCriteria no cuenta como fallo de la base un error de fn, como un
+// cliente que cortó la descarga a mitad del recorrido.
+func (r *circuitBreakerLicenseRepository) ScanByCriteria(ctx context.Context, criteria repositories.LicenseCriteria, pageSize int, fn func([]*domain.License) error) error {
+	var fnErr error
+	err := r.do(ctx, "ScanByCriteria", func() error {
+		err := r.next.ScanByCriteria(ctx, criteria, pageSize, func(licenses []*domain.License) error {
+			fnErr = fn(licenses)
+			return fnErr
+		})
+		if fnErr != nil {
+			return nil
+		}
+		return err
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return err
+}
+
+func (r *circuitBreakerLicenseRepository) FindIssuedEndingBefore(ctx context.Context, date time.Time) (licenses []*domain.License, err error) {
+	err = r.do(ctx, "FindIssuedEndingBefore", func() error {
+		licenses, err = r.next.FindIssuedEndingBefore(ctx, date)
+		return err
+	})
+	return licenses, err
+}
+
+func (r *circuitBreakerLicenseRepository) UpdateStatus(ctx context.Context, license *domain.License) error {
+	return r.do(ctx, "UpdateStatus", func() error {
+		return r.next.UpdateStatus(ctx, license)
+	})
+}
+
+func (r *circuitBreakerLicenseRepository) UpdateEmployer(ctx context.Context, license *domain.License, employer domain.Employer) error {
+	return r.do(ctx, "UpdateEmployer", func() error {
+		return r.next.UpdateEmployer(ctx, license, employer)
+	})
+}
+
+func (r *circuitBreakerLicenseRepository) UpdateAdjudication(ctx context.Context, license *domain.License) error {
+	return r.do(ctx, "UpdateAdjudication", func() error {
+		return r.next.UpdateAdjudication(ctx, license)
+	})
+}
+
+func (r *circuitBreakerLicenseRepository) SaveAmendment(ctx context.Context, license *domain.License, versions []*domain.LicenseVersion) error {
+	return r.do(ctx, "SaveAmendment", func() error {
+		return r.next.SaveAmendment(ctx, license, versions)
+	})
+}
+
+func (r *circuitBreakerLicenseRepository) FindVersions(ctx context.Context, folio string) (versions []*domain.LicenseVersion, err error) {
+	err = r.do(ctx, "FindVersions", func() error {
+		versions, err = r.next.FindVersions(ctx, folio)
+		return err
+	})
+	return versions, err
+}
+
+func (r *circuitBreakerLicenseRepository) FindVersion(ctx context.Context, folio string, version int) (licenseVersion *domain.LicenseVersion, err error) {
+	err = r.do(ctx, "FindVersion", func() error {
+		licenseVersion, err = r.next.FindVersion(ctx, folio, version)
+		return err
+	})
+	return licenseVersion, err
+}
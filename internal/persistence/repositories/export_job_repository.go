@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domain "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	entities "license-service/internal/persistence/entities"
+	errorInfo "license-service/pkg/log/error"
+	logger "license-service/pkg/log/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type exportJobRepositoryImpl struct {
+	db           *gorm.DB
+	queryTimeout time.Duration
+}
+
+func NewExportJobRepositoryImpl(db *gorm.DB, queryTimeout time.Duration) repositories.ExportJobRepository {
+	return &exportJobRepositoryImpl{
+		db:           db,
+		queryTimeout: queryTimeout,
+	}
+}
+
+func (r *exportJobRepositoryImpl) Save(ctx context.Context, job *domain.ExportJob) error {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Create(entities.ExportJobFromDomain(job))
+	if result.Error != nil {
+		var appErr *errorInfo.AppError
+
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			appErr = errorInfo.NewAppError(errorInfo.ErrConflict, "ExportJobRepository", "Save", "export job already exists: "+job.ID)
+		} else {
+			appErr = queryError("ExportJobRepository", "Save", "failed to save export job", result.Error)
+		}
+		logger.FromContext(ctx).Error("ExportJobRepository", "Save", appErr, "database insert failed")
+		return appErr
+	}
+	return nil
+}
+
+func (r *exportJobRepositoryImpl) FindByID(ctx context.Context, id string) (*domain.ExportJob, error) {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	var entity entities.ExportJobEntity
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&entity)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		appErr := queryError("ExportJobRepository", "FindByID", "failed to find export job", result.Error)
+		logger.FromContext(ctx).Error("ExportJobRepository", "FindByID", appErr, "database query failed")
+		return nil, appErr
+	}
+
+	return entity.ToDomain(), nil
+}
+
+func (r *exportJobRepositoryImpl) Update(ctx context.Context, job *domain.ExportJob) error {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	entity := entities.ExportJobFromDomain(job)
+	result := r.db.WithContext(ctx).Model(&entities.ExportJobEntity{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]interface{}{
+			"status":        entity.Status,
+			"rows":          entity.Rows,
+			"error_code":    entity.ErrorCode,
+			"error_message": entity.ErrorMessage,
+			"finished_at":   entity.FinishedAt,
+			"expires_at":    entity.ExpiresAt,
+		})
+
+	if result.Error != nil {
+		appErr := queryError("ExportJobRepository", "Update", "failed to update export job", result.Error)
+		logger.FromContext(ctx).Error("ExportJobRepository", "Update", appErr, "database update failed")
+		return appErr
+	}
+	if result.RowsAffected == 0 {
+		return errorInfo.NewAppError(errorInfo.ErrNotFound, "ExportJobRepository", "Update", "export job not found: "+job.ID)
+	}
+	return nil
+}
+
+// DeleteExpired borra en una sola sentencia y devuelve las filas borradas, así
+// dos réplicas que purgan a la vez no informan el mismo trabajo.
+func (r *exportJobRepositoryImpl) DeleteExpired(ctx context.Context, now time.Time, unfinishedBefore time.Time) ([]*domain.ExportJob, error) {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	var deleted []entities.ExportJobEntity
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Returning{}).
+		Where("expires_at <= ? OR (finished_at IS NULL AND created_at <= ?)", now, unfinishedBefore).
+		Delete(&deleted)
+
+	if result.Error != nil {
+		appErr := queryError("ExportJobRepository", "DeleteExpired", "failed to delete expired export jobs", result.Error)
+		logger.FromContext(ctx).Error("ExportJobRepository", "DeleteExpired", appErr, "database delete failed")
+		return nil, appErr
+	}
+
+	jobs := make([]*domain.ExportJob, 0, len(deleted))
+	for i := range deleted {
+		jobs = append(jobs, deleted[i].ToDomain())
+	}
+	return jobs, nil
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"license-service/internal/domain/repositories"
+	errorInfo "license-service/pkg/log/error"
+)
+
+// fileExportStore guarda los archivos de exportación en un directorio. El estado
+// de los trabajos está en la base y cualquier réplica lo consulta, pero el
+// archivo solo lo tiene la réplica que lo escribió: con varias réplicas, dir
+// debe ser un volumen montado en todas para descargar desde cualquiera.
+type fileExportStore struct {
+	dir string
+}
+
+func NewFileExportStore(dir string) repositories.ExportFileStore {
+	return &fileExportStore{dir: dir}
+}
+
+func (s *fileExportStore) Create(ctx context.Context, name string) (io.WriteCloser, error) {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return nil, errorInfo.WrapError(errorInfo.ErrInternalError, "ExportFileStore", "Create", "cannot create export directory", err)
+	}
+	file, err := os.OpenFile(s.path(name), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, errorInfo.WrapError(errorInfo.ErrInternalError, "ExportFileStore", "Create", "cannot create export file", err)
+	}
+	return file, nil
+}
+
+func (s *fileExportStore) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errorInfo.NewAppError(errorInfo.ErrNotFound, "ExportFileStore", "Open", "export file not found")
+	}
+	if err != nil {
+		return nil, errorInfo.WrapError(errorInfo.ErrInternalError, "ExportFileStore", "Open", "cannot open export file", err)
+	}
+	return file, nil
+}
+
+func (s *fileExportStore) Delete(ctx context.Context, name string) error {
+	err := os.Remove(s.path(name))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errorInfo.WrapError(errorInfo.ErrInternalError, "ExportFileStore", "Delete", "cannot delete export file", err)
+	}
+	return nil
+}
+
+func (s *fileExportStore) DeleteOlderThan(ctx context.Context, before time.Time) (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errorInfo.WrapError(errorInfo.ErrInternalError, "ExportFileStore", "DeleteOlderThan", "cannot read export directory", err)
+	}
+
+	deleted := 0
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(before) {
+			continue
+		}
+		if err := s.Delete(ctx, entry.Name()); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// path descarta cualquier directorio en name: los nombres salen de los ids de
+// trabajo, pero así un id manipulado nunca sale de dir.
+func (s *fileExportStore) path(name string) string {
+	return filepath.Join(s.dir, filepath.Base(name))
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"license-service/pkg/cache"
+	logger "license-service/pkg/log/logger"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+// licenseChangedChannel es el canal de Postgres en que cada escritura publica
+// el folio modificado, sea desde la API o desde licensectl.
+const licenseChangedChannel = "license_changed"
+
+// licenseChangeRetryDelay es la espera antes de volver a escuchar tras perder
+// la conexión.
+const licenseChangeRetryDelay = 5 * time.Second
+
+// notifyLicenseChanged publica los folios dentro de la transacción de la
+// escritura: Postgres solo entrega el aviso si la transacción se confirma.
+func notifyLicenseChanged(tx *gorm.DB, folios ...string) error {
+	return tx.Exec("SELECT pg_notify(?, folio) FROM unnest(ARRAY[?]::text[]) AS folio", licenseChangedChannel, folios).Error
+}
+
+// LicenseChangeListener escucha los avisos de licencias modificadas y descarta
+// sus entradas de la caché local, así una escritura de otra réplica o de
+// licensectl no queda oculta hasta que venza el TTL.
+type LicenseChangeListener struct {
+	db      *gorm.DB
+	backend cache.Backend
+}
+
+func NewLicenseChangeListener(db *gorm.DB, backend cache.Backend) *LicenseChangeListener {
+	return &LicenseChangeListener{db: db, backend: backend}
+}
+
+// Run escucha hasta que ctx se cancela y reconecta tras cada fallo.
+func (l *LicenseChangeListener) Run(ctx context.Context) {
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.FromContext(ctx).Error("LicenseChangeListener", "Run", err, "license change notifications interrupted, retrying")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(licenseChangeRetryDelay):
+		}
+	}
+}
+
+// listen ocupa una conexión dedicada del pool. Al terminar la descarta en vez
+// de devolverla, porque seguiría suscrita al canal.
+func (l *LicenseChangeListener) listen(ctx context.Context) error {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var listenErr error
+	_ = conn.Raw(func(driverConn any) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			listenErr = errors.New("database driver does not support LISTEN")
+			return driver.ErrBadConn
+		}
+		listenErr = l.consume(ctx, stdlibConn)
+		return driver.ErrBadConn
+	})
+	return listenErr
+}
+
+func (l *LicenseChangeListener) consume(ctx context.Context, conn *stdlib.Conn) error {
+	pgConn := conn.Conn()
+	if _, err := pgConn.Exec(ctx, "LISTEN "+licenseChangedChannel); err != nil {
+		return err
+	}
+
+	// Mientras no se escuchaba pudieron perderse avisos: se descarta todo lo
+	// cacheado antes de confiar de nuevo en el canal.
+	if err := l.backend.Clear(ctx); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("LicenseChangeListener", "consume", "listening for license changes")
+
+	for {
+		notification, err := pgConn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		if err := l.backend.Delete(ctx, licenseCacheKey(notification.Payload)); err != nil {
+			logger.FromContext(ctx).Error("LicenseChangeListener", "consume", err, "cache invalidation failed for folio: "+notification.Payload)
+		}
+	}
+}
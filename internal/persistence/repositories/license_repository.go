@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	domain "license-service/internal/domain/model"
 	"license-service/internal/domain/repositories"
@@ -15,93 +16,297 @@ import (
 )
 
 type licenseRepositoryImpl struct {
-	db     *gorm.DB
-	logger logger.Logger
+	db           *gorm.DB
+	queryTimeout time.Duration
 }
 
-func NewLicenseRepositoryImpl(db *gorm.DB) repositories.LicenseRepository {
+func NewLicenseRepositoryImpl(db *gorm.DB, queryTimeout time.Duration) repositories.LicenseRepository {
 	return &licenseRepositoryImpl{
-		db:     db,
-		logger: *logger.NewLogger(),
+		db:           db,
+		queryTimeout: queryTimeout,
 	}
 }
 
 func (r *licenseRepositoryImpl) Save(ctx context.Context, license *domain.License) error {
-	r.logger.Info("LicenseRepository", "Save", "attempting to save license with folio: "+license.Folio)
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	logger.FromContext(ctx).Info("LicenseRepository", "Save", "attempting to save license with folio: "+license.Folio)
 
 	entity := entities.FromDomain(license)
+	issuedVersion, err := entities.LicenseVersionFromDomain(license.IssuedVersion())
+	if err != nil {
+		appErr := errorInfo.WrapError(errorInfo.ErrInternalError, "LicenseRepository", "Save", "failed to encode license version", err)
+		logger.FromContext(ctx).Error("LicenseRepository", "Save", appErr)
+		return appErr
+	}
 
-	result := r.db.WithContext(ctx).Create(entity)
-	if result.Error != nil {
+	// La licencia y su versión 1 se guardan juntas para que el historial nunca
+	// quede incompleto.
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entity).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(issuedVersion).Error; err != nil {
+			return err
+		}
+		return notifyLicenseChanged(tx, license.Folio)
+	})
+	if err != nil {
 		var appErr *errorInfo.AppError
 
-		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			appErr = errorInfo.NewAppError(
-				errorInfo.ErrInvalidData,
+				errorInfo.ErrConflict,
 				"LicenseRepository",
 				"Save",
 				"license with this folio already exists",
 			)
-			r.logger.Error("LicenseRepository", "Save", appErr, "duplicate folio: "+license.Folio)
+			logger.FromContext(ctx).Error("LicenseRepository", "Save", appErr, "duplicate folio: "+license.Folio)
 		} else {
+			appErr = queryError("LicenseRepository", "Save", "failed to save license", err)
+			logger.FromContext(ctx).Error("LicenseRepository", "Save", appErr, "database insert failed")
+		}
+		return appErr
+	}
+
+	logger.FromContext(ctx).Info("LicenseRepository", "Save", fmt.Sprintf("license saved successfully with ID: %d", entity.ID))
+	return nil
+}
+
+func (r *licenseRepositoryImpl) SaveBatch(ctx context.Context, licenses []*domain.License) error {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	logger.FromContext(ctx).Info("LicenseRepository", "SaveBatch", fmt.Sprintf("attempting to save %d licenses", len(licenses)))
+
+	if len(licenses) == 0 {
+		return nil
+	}
+
+	licenseEntities := make([]*entities.LicenseEntity, 0, len(licenses))
+	versionEntities := make([]*entities.LicenseVersionEntity, 0, len(licenses))
+	for _, license := range licenses {
+		issuedVersion, err := entities.LicenseVersionFromDomain(license.IssuedVersion())
+		if err != nil {
+			appErr := errorInfo.WrapError(errorInfo.ErrInternalError, "LicenseRepository", "SaveBatch", "failed to encode license version", err)
+			logger.FromContext(ctx).Error("LicenseRepository", "SaveBatch", appErr, "folio: "+license.Folio)
+			return appErr
+		}
+		licenseEntities = append(licenseEntities, entities.FromDomain(license))
+		versionEntities = append(versionEntities, issuedVersion)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(licenseEntities).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(versionEntities).Error; err != nil {
+			return err
+		}
+		folios := make([]string, 0, len(licenses))
+		for _, license := range licenses {
+			folios = append(folios, license.Folio)
+		}
+		return notifyLicenseChanged(tx, folios...)
+	})
+	if err != nil {
+		var appErr *errorInfo.AppError
+
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			appErr = errorInfo.NewAppError(
-				errorInfo.ErrInternalError,
+				errorInfo.ErrConflict,
 				"LicenseRepository",
-				"Save",
-				fmt.Sprintf("failed to save license: %v", result.Error),
+				"SaveBatch",
+				"a license with one of these folios already exists",
 			)
-			r.logger.Error("LicenseRepository", "Save", appErr, "database insert failed")
+			logger.FromContext(ctx).Error("LicenseRepository", "SaveBatch", appErr, "duplicate folio in batch")
+		} else {
+			appErr = queryError("LicenseRepository", "SaveBatch", "failed to save licenses", err)
+			logger.FromContext(ctx).Error("LicenseRepository", "SaveBatch", appErr, "database insert failed")
 		}
 		return appErr
 	}
 
-	r.logger.Info("LicenseRepository", "Save", fmt.Sprintf("license saved successfully with ID: %d", entity.ID))
+	logger.FromContext(ctx).Info("LicenseRepository", "SaveBatch", fmt.Sprintf("%d licenses saved successfully", len(licenses)))
 	return nil
 }
 
 func (r *licenseRepositoryImpl) FindByFolio(ctx context.Context, folio string) (*domain.License, error) {
-	r.logger.Info("LicenseRepository", "FindByFolio", "searching for folio: "+folio)
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	logger.FromContext(ctx).Info("LicenseRepository", "FindByFolio", "searching for folio: "+folio)
 
 	var entity entities.LicenseEntity
-	result := r.db.WithContext(ctx).Where("folio = ?", folio).First(&entity)
+	result := r.db.WithContext(ctx).Preload("Employers", orderEmployers).Where("folio = ?", folio).First(&entity)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			r.logger.Info("LicenseRepository", "FindByFolio", "license not found for folio: "+folio)
+			logger.FromContext(ctx).Info("LicenseRepository", "FindByFolio", "license not found for folio: "+folio)
 			return nil, nil
 		}
 
-		appErr := errorInfo.NewAppError(
-			errorInfo.ErrInternalError,
-			"LicenseRepository",
-			"FindByFolio",
-			fmt.Sprintf("failed to find license: %v", result.Error),
-		)
-		r.logger.Error("LicenseRepository", "FindByFolio", appErr, "database query failed")
+		appErr := queryError("LicenseRepository", "FindByFolio", "failed to find license", result.Error)
+		logger.FromContext(ctx).Error("LicenseRepository", "FindByFolio", appErr, "database query failed")
 		return nil, appErr
 	}
 
-	r.logger.Info("LicenseRepository", "FindByFolio", "license found for folio: "+folio)
+	logger.FromContext(ctx).Info("LicenseRepository", "FindByFolio", "license found for folio: "+folio)
 	return entity.ToDomain(), nil
 }
 
-func (r *licenseRepositoryImpl) FindByPatientID(ctx context.Context, patientID string) ([]*domain.License, error) {
-	r.logger.Info("LicenseRepository", "FindByPatientID", "searching licenses for patient: "+patientID)
+func (r *licenseRepositoryImpl) FindByFolios(ctx context.Context, folios []string) ([]*domain.License, error) {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	logger.FromContext(ctx).Info("LicenseRepository", "FindByFolios", fmt.Sprintf("searching %d folios", len(folios)))
+
+	if len(folios) == 0 {
+		return []*domain.License{}, nil
+	}
+
+	var entities []entities.LicenseEntity
+	result := r.db.WithContext(ctx).Preload("Employers", orderEmployers).Where("folio IN ?", folios).Find(&entities)
+
+	if result.Error != nil {
+		appErr := queryError("LicenseRepository", "FindByFolios", "failed to query licenses", result.Error)
+		logger.FromContext(ctx).Error("LicenseRepository", "FindByFolios", appErr, "database query failed")
+		return nil, appErr
+	}
+
+	licenses := make([]*domain.License, 0, len(entities))
+	for _, entity := range entities {
+		licenses = append(licenses, entity.ToDomain())
+	}
+
+	logger.FromContext(ctx).Info("LicenseRepository", "FindByFolios", fmt.Sprintf("found %d of %d folios", len(licenses), len(folios)))
+	return licenses, nil
+}
+
+func (r *licenseRepositoryImpl) FindByCriteria(ctx context.Context, criteria repositories.LicenseCriteria) ([]*domain.License, error) {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	logger.FromContext(ctx).Info("LicenseRepository", "FindByCriteria", fmt.Sprintf("searching licenses for patient: %s employer: %s type: %s status: %s", criteria.PatientID, criteria.EmployerRut, criteria.Type, criteria.Status))
+
+	query := r.criteriaQuery(r.db.WithContext(ctx).Preload("Employers", orderEmployers), criteria)
+
+	var entities []entities.LicenseEntity
+	result := query.Order("created_at DESC").Find(&entities)
+
+	if result.Error != nil {
+		appErr := queryError("LicenseRepository", "FindByCriteria", "failed to query licenses", result.Error)
+		logger.FromContext(ctx).Error("LicenseRepository", "FindByCriteria", appErr, "database query failed")
+		return nil, appErr
+	}
+
+	licenses := make([]*domain.License, 0, len(entities))
+	for _, entity := range entities {
+		licenses = append(licenses, entity.ToDomain())
+	}
+
+	logger.FromContext(ctx).Info("LicenseRepository", "FindByCriteria", fmt.Sprintf("found %d licenses for patient: %s", len(licenses), criteria.PatientID))
+	return licenses, nil
+}
+
+func (r *licenseRepositoryImpl) ScanByCriteria(ctx context.Context, criteria repositories.LicenseCriteria, pageSize int, fn func([]*domain.License) error) error {
+	logger.FromContext(ctx).Info("LicenseRepository", "ScanByCriteria", fmt.Sprintf("scanning licenses for patient: %s type: %s status: %s in pages of %d", criteria.PatientID, criteria.Type, criteria.Status, pageSize))
+
+	// Paginación por id: cada página es una consulta con su propio timeout, así
+	// un recorrido largo no choca con DB_QUERY_TIMEOUT.
+	var lastID uint
+	scanned := 0
+	for {
+		page, err := r.scanPage(ctx, criteria, lastID, pageSize)
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		licenses := make([]*domain.License, 0, len(page))
+		for _, entity := range page {
+			licenses = append(licenses, entity.ToDomain())
+		}
+		if err := fn(licenses); err != nil {
+			return err
+		}
+
+		scanned += len(page)
+		lastID = page[len(page)-1].ID
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	logger.FromContext(ctx).Info("LicenseRepository", "ScanByCriteria", fmt.Sprintf("scanned %d licenses", scanned))
+	return nil
+}
+
+func (r *licenseRepositoryImpl) scanPage(ctx context.Context, criteria repositories.LicenseCriteria, afterID uint, pageSize int) ([]entities.LicenseEntity, error) {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	var page []entities.LicenseEntity
+	query := r.criteriaQuery(r.db.WithContext(ctx).Preload("Employers", orderEmployers), criteria)
+	result := query.Where("id > ?", afterID).Order("id").Limit(pageSize).Find(&page)
+
+	if result.Error != nil {
+		appErr := queryError("LicenseRepository", "ScanByCriteria", "failed to query licenses", result.Error)
+		logger.FromContext(ctx).Error("LicenseRepository", "ScanByCriteria", appErr, "database query failed")
+		return nil, appErr
+	}
+	return page, nil
+}
+
+// criteriaQuery aplica a query los filtros no vacíos de criteria.
+func (r *licenseRepositoryImpl) criteriaQuery(query *gorm.DB, criteria repositories.LicenseCriteria) *gorm.DB {
+	if criteria.PatientID != "" {
+		query = query.Where("patient_id = ?", criteria.PatientID)
+	}
+	if criteria.Type != "" {
+		query = query.Where("type = ?", criteria.Type)
+	}
+	if criteria.Status != "" {
+		query = query.Where("status = ?", criteria.Status)
+	}
+	if criteria.EmployerRut != "" {
+		employers := r.db.Model(&entities.LicenseEmployerEntity{}).Select("license_id").Where("employer_rut = ?", criteria.EmployerRut)
+		if criteria.Acknowledged != nil {
+			if *criteria.Acknowledged {
+				employers = employers.Where("acknowledged_at IS NOT NULL")
+			} else {
+				employers = employers.Where("acknowledged_at IS NULL")
+			}
+		}
+		query = query.Where("id IN (?)", employers)
+	}
+	return query
+}
+
+// FindIssuedEndingBefore devuelve las licencias aún emitidas cuyo último día de
+// reposo autorizado (start_date + días - 1, con los días aprobados si la
+// licencia fue reducida) es anterior a date.
+func (r *licenseRepositoryImpl) FindIssuedEndingBefore(ctx context.Context, date time.Time) ([]*domain.License, error) {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	logger.FromContext(ctx).Info("LicenseRepository", "FindIssuedEndingBefore", "searching issued licenses ending before: "+date.Format("2006-01-02"))
 
 	var entities []entities.LicenseEntity
 	result := r.db.WithContext(ctx).
-		Where("patient_id = ?", patientID).
-		Order("created_at DESC").
+		Preload("Employers", orderEmployers).
+		Where("status = ?", domain.StatusIssued).
+		Where("start_date + (CASE WHEN adjudication_status = ? AND approved_days IS NOT NULL THEN approved_days ELSE days END - 1) < ?",
+			domain.AdjudicationReduced, date.Format("2006-01-02")).
+		Order("start_date ASC").
 		Find(&entities)
 
 	if result.Error != nil {
-		appErr := errorInfo.NewAppError(
-			errorInfo.ErrInternalError,
-			"LicenseRepository",
-			"FindByPatientID",
-			fmt.Sprintf("failed to query licenses: %v", result.Error),
-		)
-		r.logger.Error("LicenseRepository", "FindByPatientID", appErr, "database query failed")
+		appErr := queryError("LicenseRepository", "FindIssuedEndingBefore", "failed to query licenses", result.Error)
+		logger.FromContext(ctx).Error("LicenseRepository", "FindIssuedEndingBefore", appErr, "database query failed")
 		return nil, appErr
 	}
 
@@ -109,7 +314,220 @@ func (r *licenseRepositoryImpl) FindByPatientID(ctx context.Context, patientID s
 	for _, entity := range entities {
 		licenses = append(licenses, entity.ToDomain())
 	}
-
-	r.logger.Info("LicenseRepository", "FindByPatientID", fmt.Sprintf("found %d licenses for patient: %s", len(licenses), patientID))
 	return licenses, nil
 }
+
+func (r *licenseRepositoryImpl) UpdateStatus(ctx context.Context, license *domain.License) error {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	logger.FromContext(ctx).Info("LicenseRepository", "UpdateStatus", "updating status of folio: "+license.Folio+" to "+license.Status)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return updateAtRevision(tx, license, map[string]interface{}{
+			"status": license.Status,
+		})
+	})
+	if err != nil {
+		return r.updateError(ctx, "UpdateStatus", license, "failed to update license", err)
+	}
+
+	license.Revision = license.CurrentRevision() + 1
+	return nil
+}
+
+// UpdateEmployer guarda la recepción de la licencia por uno de sus empleadores.
+func (r *licenseRepositoryImpl) UpdateEmployer(ctx context.Context, license *domain.License, employer domain.Employer) error {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	logger.FromContext(ctx).Info("LicenseRepository", "UpdateEmployer", "updating employer "+employer.Rut+" of folio: "+license.Folio)
+
+	var missing bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateAtRevision(tx, license, map[string]interface{}{}); err != nil {
+			return err
+		}
+
+		licenseID := tx.Model(&entities.LicenseEntity{}).Select("id").Where("folio = ?", license.Folio)
+		result := tx.Model(&entities.LicenseEmployerEntity{}).
+			Where("license_id = (?)", licenseID).
+			Where("employer_rut = ?", employer.Rut).
+			Update("acknowledged_at", employer.AcknowledgedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			missing = true
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+
+	if missing {
+		return errorInfo.NewAppError(errorInfo.ErrNotFound, "LicenseRepository", "UpdateEmployer", "license employer not found")
+	}
+	if err != nil {
+		return r.updateError(ctx, "UpdateEmployer", license, "failed to update license employer", err)
+	}
+
+	license.Revision = license.CurrentRevision() + 1
+	return nil
+}
+
+// UpdateAdjudication guarda la aseguradora y su resolución sobre la licencia.
+func (r *licenseRepositoryImpl) UpdateAdjudication(ctx context.Context, license *domain.License) error {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	logger.FromContext(ctx).Info("LicenseRepository", "UpdateAdjudication", "updating adjudication of folio: "+license.Folio+" to "+license.Adjudication.Status)
+
+	entity := entities.FromDomain(license)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return updateAtRevision(tx, license, map[string]interface{}{
+			"insurer_rut":         entity.InsurerRut,
+			"adjudication_status": entity.AdjudicationStatus,
+			"resolution_reason":   entity.ResolutionReason,
+			"approved_days":       entity.ApprovedDays,
+			"resolved_at":         entity.ResolvedAt,
+			"resolved_by":         entity.ResolvedBy,
+		})
+	})
+	if err != nil {
+		return r.updateError(ctx, "UpdateAdjudication", license, "failed to update license adjudication", err)
+	}
+
+	license.Revision = license.CurrentRevision() + 1
+	return nil
+}
+
+// SaveAmendment guarda los campos enmendados y las nuevas versiones en una
+// transacción.
+func (r *licenseRepositoryImpl) SaveAmendment(ctx context.Context, license *domain.License, versions []*domain.LicenseVersion) error {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	logger.FromContext(ctx).Info("LicenseRepository", "SaveAmendment", fmt.Sprintf("saving version %d of folio: %s", license.Version, license.Folio))
+
+	versionEntities := make([]*entities.LicenseVersionEntity, 0, len(versions))
+	for _, version := range versions {
+		versionEntity, err := entities.LicenseVersionFromDomain(version)
+		if err != nil {
+			appErr := errorInfo.WrapError(errorInfo.ErrInternalError, "LicenseRepository", "SaveAmendment", "failed to encode license version", err)
+			logger.FromContext(ctx).Error("LicenseRepository", "SaveAmendment", appErr)
+			return appErr
+		}
+		versionEntities = append(versionEntities, versionEntity)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := updateAtRevision(tx, license, map[string]interface{}{
+			"diagnosis":     license.Diagnosis,
+			"rest_type":     license.RestType,
+			"rest_location": license.RestLocation,
+			"rest_address":  license.RestAddress,
+			"version":       license.Version,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.Create(versionEntities).Error
+	})
+	if err != nil {
+		return r.updateError(ctx, "SaveAmendment", license, "failed to save license amendment", err)
+	}
+
+	license.Revision = license.CurrentRevision() + 1
+	return nil
+}
+
+func (r *licenseRepositoryImpl) FindVersions(ctx context.Context, folio string) ([]*domain.LicenseVersion, error) {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	var versionEntities []entities.LicenseVersionEntity
+	result := r.db.WithContext(ctx).Where("folio = ?", folio).Order("version ASC").Find(&versionEntities)
+	if result.Error != nil {
+		appErr := queryError("LicenseRepository", "FindVersions", "failed to query license versions", result.Error)
+		logger.FromContext(ctx).Error("LicenseRepository", "FindVersions", appErr, "database query failed")
+		return nil, appErr
+	}
+
+	versions := make([]*domain.LicenseVersion, 0, len(versionEntities))
+	for _, versionEntity := range versionEntities {
+		version, err := versionEntity.ToDomain()
+		if err != nil {
+			appErr := errorInfo.WrapError(errorInfo.ErrInternalError, "LicenseRepository", "FindVersions", "failed to decode license version", err)
+			logger.FromContext(ctx).Error("LicenseRepository", "FindVersions", appErr)
+			return nil, appErr
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+func (r *licenseRepositoryImpl) FindVersion(ctx context.Context, folio string, version int) (*domain.LicenseVersion, error) {
+	ctx, cancel := queryContext(ctx, r.queryTimeout)
+	defer cancel()
+
+	var versionEntity entities.LicenseVersionEntity
+	result := r.db.WithContext(ctx).Where("folio = ? AND version = ?", folio, version).First(&versionEntity)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			logger.FromContext(ctx).Info("LicenseRepository", "FindVersion", fmt.Sprintf("version %d not found for folio: %s", version, folio))
+			return nil, nil
+		}
+
+		appErr := queryError("LicenseRepository", "FindVersion", "failed to find license version", result.Error)
+		logger.FromContext(ctx).Error("LicenseRepository", "FindVersion", appErr, "database query failed")
+		return nil, appErr
+	}
+
+	licenseVersion, err := versionEntity.ToDomain()
+	if err != nil {
+		appErr := errorInfo.WrapError(errorInfo.ErrInternalError, "LicenseRepository", "FindVersion", "failed to decode license version", err)
+		logger.FromContext(ctx).Error("LicenseRepository", "FindVersion", appErr)
+		return nil, appErr
+	}
+	return licenseVersion, nil
+}
+
+// errStaleRevision indica que la licencia ya no estaba en la revisión leída.
+var errStaleRevision = errors.New("stale license revision")
+
+// updateAtRevision aplica values solo si la licencia sigue en la revisión que se
+// leyó y avanza la revisión en la misma sentencia. Las licencias no se borran,
+// así que cero filas afectadas significa que otra escritura se adelantó. Debe
+// correr en una transacción para que el aviso de cambio salga solo al confirmar.
+func updateAtRevision(db *gorm.DB, license *domain.License, values map[string]interface{}) error {
+	values["revision"] = gorm.Expr("revision + 1")
+	result := db.Model(&entities.LicenseEntity{}).
+		Where("folio = ? AND revision = ?", license.Folio, license.CurrentRevision()).
+		Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errStaleRevision
+	}
+	return notifyLicenseChanged(db, license.Folio)
+}
+
+// updateError traduce el fallo de una escritura. Una revisión vencida o una
+// versión duplicada son escrituras concurrentes, no fallos de la base.
+func (r *licenseRepositoryImpl) updateError(ctx context.Context, operation string, license *domain.License, message string, err error) *errorInfo.AppError {
+	if errors.Is(err, errStaleRevision) || errors.Is(err, gorm.ErrDuplicatedKey) {
+		appErr := errorInfo.NewAppError(errorInfo.ErrOptimisticLockFailed, "LicenseRepository", operation, "license was modified concurrently, reload it and retry")
+		logger.FromContext(ctx).Error("LicenseRepository", operation, appErr, fmt.Sprintf("stale revision %d for folio: %s", license.CurrentRevision(), license.Folio))
+		return appErr
+	}
+
+	appErr := queryError("LicenseRepository", operation, message, err)
+	logger.FromContext(ctx).Error("LicenseRepository", operation, appErr, "database update failed")
+	return appErr
+}
+
+func orderEmployers(db *gorm.DB) *gorm.DB {
+	return db.Order("id ASC")
+}
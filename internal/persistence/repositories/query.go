@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	errorInfo "license-service/pkg/log/error"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// queryContext acota una consulta a queryTimeout. Si el contexto de la petición
+// ya trae un deadline más corto, ese es el que manda.
+func queryContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// queryError traduce un error de GORM a AppError. Los timeouts se reportan como
+// ErrDBTimeout, la base inalcanzable como ErrDBConnection, las cancelaciones del
+// cliente como ErrRequestTimeout y las restricciones violadas como errores del
+// cliente, para que el circuit breaker solo cuente los fallos de la base.
+func queryError(component, operation, message string, err error) *errorInfo.AppError {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return errorInfo.WrapError(errorInfo.ErrDBTimeout, component, operation, message+": query timed out", err)
+	case errors.Is(err, context.Canceled):
+		return errorInfo.WrapError(errorInfo.ErrRequestTimeout, component, operation, message+": request canceled", err)
+	case isConnectionFailure(err):
+		return errorInfo.WrapError(errorInfo.ErrDBConnection, component, operation, message+": database unavailable", err)
+	case errors.Is(err, gorm.ErrDuplicatedKey) || hasSQLState(err, "23505"):
+		return errorInfo.WrapError(errorInfo.ErrConflict, component, operation, message+": duplicate key", err)
+	case isConstraintViolation(err):
+		return errorInfo.WrapError(errorInfo.ErrInvalidData, component, operation, message+": constraint violated", err)
+	default:
+		return errorInfo.NewAppError(errorInfo.ErrInternalError, component, operation, fmt.Sprintf("%s: %v", message, err))
+	}
+}
+
+// isConnectionFailure reconoce una base inalcanzable o que cortó la conexión
+// (SQLSTATE clase 08, o 57P01-57P03 durante un reinicio).
+func isConnectionFailure(err error) bool {
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	if errors.As(err, &connectErr) || errors.As(err, &netErr) || errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "08") || strings.HasPrefix(pgErr.Code, "57P"))
+}
+
+// isConstraintViolation reconoce los datos que la base rechaza por una
+// restricción de integridad (SQLSTATE clase 23).
+func isConstraintViolation(err error) bool {
+	if errors.Is(err, gorm.ErrForeignKeyViolated) || errors.Is(err, gorm.ErrCheckConstraintViolated) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && strings.HasPrefix(pgErr.Code, "23")
+}
+
+func hasSQLState(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	domain "license-service/internal/domain/model"
+	"license-service/internal/domain/repositories"
+	"license-service/pkg/circuitbreaker"
+	errorInfo "license-service/pkg/log/error"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+func TestQueryErrorClassification(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		want           errorInfo.ErrorCode
+		infrastructure bool
+	}{
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), errorInfo.ErrDBTimeout, true},
+		{"canceled", context.Canceled, errorInfo.ErrRequestTimeout, false},
+		{"connect error", &pgconn.ConnectError{}, errorInfo.ErrDBConnection, true},
+		{"network error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, errorInfo.ErrDBConnection, true},
+		{"bad connection", driver.ErrBadConn, errorInfo.ErrDBConnection, true},
+		{"connection exception", &pgconn.PgError{Code: "08006"}, errorInfo.ErrDBConnection, true},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, errorInfo.ErrDBConnection, true},
+		{"translated duplicate", gorm.ErrDuplicatedKey, errorInfo.ErrConflict, false},
+		{"raw duplicate", &pgconn.PgError{Code: "23505"}, errorInfo.ErrConflict, false},
+		{"foreign key", gorm.ErrForeignKeyViolated, errorInfo.ErrInvalidData, false},
+		{"check constraint", gorm.ErrCheckConstraintViolated, errorInfo.ErrInvalidData, false},
+		{"not null", &pgconn.PgError{Code: "23502"}, errorInfo.ErrInvalidData, false},
+		{"syntax error", &pgconn.PgError{Code: "42601"}, errorInfo.ErrInternalError, false},
+		{"unknown", errors.New("boom"), errorInfo.ErrInternalError, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := queryError("Test", "op", "failed", tt.err)
+			if appErr.Code != tt.want {
+				t.Errorf("code = %s, want %s", appErr.Code, tt.want)
+			}
+			if got := IsInfrastructureFailure(appErr); got != tt.infrastructure {
+				t.Errorf("IsInfrastructureFailure = %v, want %v", got, tt.infrastructure)
+			}
+		})
+	}
+}
+
+func TestIsInfrastructureFailureIgnoresPlainErrors(t *testing.T) {
+	if IsInfrastructureFailure(errors.New("boom")) {
+		t.Error("a non-AppError counted as an infrastructure failure")
+	}
+	if IsInfrastructureFailure(errorInfo.NewAppError(errorInfo.ErrNotFound, "Test", "op", "missing")) {
+		t.Error("a not found counted as an infrastructure failure")
+	}
+}
+
+// failingLicenseRepository falla Save con err; el resto no se usa.
+type failingLicenseRepository struct {
+	repositories.LicenseRepository
+	err error
+}
+
+func (r *failingLicenseRepository) Save(context.Context, *domain.License) error {
+	return r.err
+}
+
+func TestCircuitBreakerRepositoryCountsOnlyInfrastructureFailures(t *testing.T) {
+	newRepository := func(err error) repositories.LicenseRepository {
+		breaker := circuitbreaker.New(circuitbreaker.Config{
+			FailureThreshold: 3,
+			OpenTimeout:      time.Minute,
+			IsFailure:        IsInfrastructureFailure,
+		})
+		return NewCircuitBreakerLicenseRepository(&failingLicenseRepository{err: err}, breaker)
+	}
+	license := &domain.License{Folio: "L-1"}
+
+	duplicate := newRepository(queryError("LicenseRepository", "Save", "failed", &pgconn.PgError{Code: "23505"}))
+	for i := 0; i < 10; i++ {
+		err := duplicate.Save(context.Background(), license)
+		if !errorInfo.IsAppErrorCode(err, string(errorInfo.ErrConflict)) {
+			t.Fatalf("Save #%d = %v, want the conflict", i+1, err)
+		}
+	}
+
+	unreachable := newRepository(queryError("LicenseRepository", "Save", "failed", &pgconn.ConnectError{}))
+	for i := 0; i < 3; i++ {
+		_ = unreachable.Save(context.Background(), license)
+	}
+	err := unreachable.Save(context.Background(), license)
+	if !errorInfo.IsAppErrorCode(err, string(errorInfo.ErrCircuitBreakerOpen)) {
+		t.Fatalf("Save after 3 connection failures = %v, want the circuit open", err)
+	}
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"license-service/internal/application/dto"
+	"license-service/internal/application/usecase/contrats"
+	handler "license-service/pkg/handler"
+	errors "license-service/pkg/log/error"
+	logs "license-service/pkg/log/logger"
+
+	"github.com/gorilla/mux"
+)
+
+type APIKeyController struct {
+	apiKeyCreatorUseCase contrats.APIKeyCreator
+	apiKeyListerUseCase  contrats.APIKeyLister
+	apiKeyRotatorUseCase contrats.APIKeyRotator
+	apiKeyRevokerUseCase contrats.APIKeyRevoker
+}
+
+// APIKeyUseCases agrupa los casos de uso que expone APIKeyController.
+type APIKeyUseCases struct {
+	Creator contrats.APIKeyCreator
+	Lister  contrats.APIKeyLister
+	Rotator contrats.APIKeyRotator
+	Revoker contrats.APIKeyRevoker
+}
+
+func NewAPIKeyController(useCases APIKeyUseCases) *APIKeyController {
+	return &APIKeyController{
+		apiKeyCreatorUseCase: useCases.Creator,
+		apiKeyListerUseCase:  useCases.Lister,
+		apiKeyRotatorUseCase: useCases.Rotator,
+		apiKeyRevokerUseCase: useCases.Revoker,
+	}
+}
+
+func (kc *APIKeyController) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateAPIKeyDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInvalidData,
+			"APIKeyController",
+			"CreateAPIKey",
+			"failed to decode request body",
+		)
+		logs.FromContext(r.Context()).Error("APIKeyController", "CreateAPIKey", AppErr, "invalid JSON in request body")
+		handler.WriteErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	key, err := kc.apiKeyCreatorUseCase.Execute(r.Context(), req)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("APIKeyController", "CreateAPIKey", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	kc.writeJSON(w, r, "CreateAPIKey", http.StatusCreated, key)
+}
+
+func (kc *APIKeyController) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := kc.apiKeyListerUseCase.Execute(r.Context())
+	if err != nil {
+		logs.FromContext(r.Context()).Error("APIKeyController", "ListAPIKeys", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	kc.writeJSON(w, r, "ListAPIKeys", http.StatusOK, keys)
+}
+
+func (kc *APIKeyController) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	prefix := mux.Vars(r)["prefix"]
+
+	key, err := kc.apiKeyRotatorUseCase.Execute(r.Context(), prefix)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("APIKeyController", "RotateAPIKey", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	kc.writeJSON(w, r, "RotateAPIKey", http.StatusCreated, key)
+}
+
+func (kc *APIKeyController) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	prefix := mux.Vars(r)["prefix"]
+
+	key, err := kc.apiKeyRevokerUseCase.Execute(r.Context(), prefix)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("APIKeyController", "RevokeAPIKey", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	kc.writeJSON(w, r, "RevokeAPIKey", http.StatusOK, key)
+}
+
+func (kc *APIKeyController) writeJSON(w http.ResponseWriter, r *http.Request, operation string, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInternalError,
+			"APIKeyController",
+			operation,
+			"failed to encode response",
+		)
+		logs.FromContext(r.Context()).Error("APIKeyController", operation, AppErr, "response encoding failed")
+	}
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"license-service/internal/application/dto"
+	"license-service/internal/application/usecase/contrats"
+	handler "license-service/pkg/handler"
+	errors "license-service/pkg/log/error"
+	logs "license-service/pkg/log/logger"
+
+	"github.com/gorilla/mux"
+)
+
+type EmployerController struct {
+	employerLicensesRetrieverUseCase contrats.EmployerLicensesRetriever
+	employerAcknowledgerUseCase      contrats.EmployerAcknowledger
+}
+
+// EmployerUseCases agrupa los casos de uso que expone EmployerController.
+type EmployerUseCases struct {
+	LicensesRetriever contrats.EmployerLicensesRetriever
+	Acknowledger      contrats.EmployerAcknowledger
+}
+
+func NewEmployerController(useCases EmployerUseCases) *EmployerController {
+	return &EmployerController{
+		employerLicensesRetrieverUseCase: useCases.LicensesRetriever,
+		employerAcknowledgerUseCase:      useCases.Acknowledger,
+	}
+}
+
+func (ec *EmployerController) GetEmployerLicenses(w http.ResponseWriter, r *http.Request) {
+	filter := dto.EmployerLicenseFilterDTO{
+		EmployerRut: mux.Vars(r)["rut"],
+		Status:      r.URL.Query().Get("status"),
+	}
+
+	if value := r.URL.Query().Get("acknowledged"); value != "" {
+		acknowledged, err := strconv.ParseBool(value)
+		if err != nil {
+			logs.FromContext(r.Context()).Error("EmployerController", "GetEmployerLicenses", err, "invalid acknowledged parameter: "+value)
+			handler.WriteDetailedErrorResponse(w, http.StatusBadRequest, "INVALID_DATA", "acknowledged must be true or false")
+			return
+		}
+		filter.Acknowledged = &acknowledged
+	}
+
+	licenses, err := ec.employerLicensesRetrieverUseCase.Execute(r.Context(), filter)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("EmployerController", "GetEmployerLicenses", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	ec.writeJSON(w, r, "GetEmployerLicenses", http.StatusOK, licenses)
+}
+
+func (ec *EmployerController) AcknowledgeLicense(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	revision, ok := ifMatchRevision(w, r, "EmployerController", "AcknowledgeLicense")
+	if !ok {
+		return
+	}
+
+	license, err := ec.employerAcknowledgerUseCase.Execute(r.Context(), vars["folio"], vars["rut"], revision)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("EmployerController", "AcknowledgeLicense", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", licenseETag(license.Revision))
+	ec.writeJSON(w, r, "AcknowledgeLicense", http.StatusOK, license)
+}
+
+func (ec *EmployerController) writeJSON(w http.ResponseWriter, r *http.Request, operation string, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInternalError,
+			"EmployerController",
+			operation,
+			"failed to encode response",
+		)
+		logs.FromContext(r.Context()).Error("EmployerController", operation, AppErr, "response encoding failed")
+	}
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"license-service/pkg/health"
+	errors "license-service/pkg/log/error"
+	logs "license-service/pkg/log/logger"
+)
+
+type HealthController struct {
+	registry *health.Registry
+}
+
+func NewHealthController(registry *health.Registry) *HealthController {
+	return &HealthController{
+		registry: registry,
+	}
+}
+
+// Liveness solo indica que el proceso responde; no consulta dependencias.
+func (hc *HealthController) Liveness(w http.ResponseWriter, r *http.Request) {
+	hc.writeJSON(w, r, "Liveness", http.StatusOK, map[string]string{"status": health.StatusUp})
+}
+
+func (hc *HealthController) Readiness(w http.ResponseWriter, r *http.Request) {
+	report := hc.registry.Run(r.Context())
+	logFailedChecks(r, "Readiness", report)
+
+	if report.Status != health.StatusUp {
+		code := errors.ErrHealthCheckFailed
+		if report.ShuttingDown {
+			code = errors.ErrShutdownInProgress
+		}
+		logs.FromContext(r.Context()).Info("HealthController", "Readiness", "service not ready: "+string(code))
+		hc.writeJSON(w, r, "Readiness", http.StatusServiceUnavailable, map[string]interface{}{
+			"status": report.Status,
+			"error":  code,
+			"checks": report.Checks,
+		})
+		return
+	}
+
+	hc.writeJSON(w, r, "Readiness", http.StatusOK, map[string]string{"status": report.Status})
+}
+
+func (hc *HealthController) Details(w http.ResponseWriter, r *http.Request) {
+	report := hc.registry.Run(r.Context())
+	logFailedChecks(r, "Details", report)
+
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	hc.writeJSON(w, r, "Details", status, report)
+}
+
+// logFailedChecks deja en el log el error de cada dependencia caída, que la
+// respuesta no incluye.
+func logFailedChecks(r *http.Request, operation string, report health.Report) {
+	for _, check := range report.Checks {
+		if check.Error != nil {
+			logs.FromContext(r.Context()).Error("HealthController", operation, check.Error, "health check failed: "+check.Name)
+		}
+	}
+}
+
+func (hc *HealthController) writeJSON(w http.ResponseWriter, r *http.Request, operation string, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInternalError,
+			"HealthController",
+			operation,
+			"failed to encode response",
+		)
+		logs.FromContext(r.Context()).Error("HealthController", operation, AppErr, "response encoding failed")
+	}
+}
@@ -2,8 +2,12 @@ package controller
 
 import (
 	"encoding/json"
+	stderrors "errors"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"license-service/internal/application/dto"
 	"license-service/internal/application/usecase/contrats"
@@ -18,28 +22,62 @@ type LicenseController struct {
 	issueLicenseUseCase               contrats.LicenseIssuer
 	retrieveLicenseUseCase            contrats.LicenseRetriever
 	licenseVerifierUseCase            contrats.LicenseVerifier
+	licenseBatchVerifierUseCase       contrats.LicenseBatchVerifier
 	licensesByPatientRetrieverUseCase contrats.LicensesByPatientRetriever
-	logger                            logs.Logger
+	licenseResolverUseCase            contrats.LicenseResolver
+	licenseVersionRetrieverUseCase    contrats.LicenseVersionRetriever
+	licenseVersionsRetrieverUseCase   contrats.LicenseVersionsRetriever
+	licenseAmenderUseCase             contrats.LicenseAmender
+	licenseImporterUseCase            contrats.LicenseImporter
+	licenseExporterUseCase            contrats.LicenseExporter
+	exportJobStarterUseCase           contrats.ExportJobStarter
+	exportJobRetrieverUseCase         contrats.ExportJobRetriever
+	exportJobDownloaderUseCase        contrats.ExportJobDownloader
+	importMaxBytes                    int64
 }
 
-func NewLicenseController(
-	issueLicenseUseCase contrats.LicenseIssuer,
-	retrieveLicenseUseCase contrats.LicenseRetriever,
-	licenseVerifierUseCase contrats.LicenseVerifier,
-	licensesByPatientRetrieverUseCase contrats.LicensesByPatientRetriever,
-) *LicenseController {
+// LicenseUseCases agrupa los casos de uso que expone LicenseController.
+type LicenseUseCases struct {
+	Issuer              contrats.LicenseIssuer
+	Retriever           contrats.LicenseRetriever
+	Verifier            contrats.LicenseVerifier
+	BatchVerifier       contrats.LicenseBatchVerifier
+	ByPatientRetriever  contrats.LicensesByPatientRetriever
+	Resolver            contrats.LicenseResolver
+	VersionRetriever    contrats.LicenseVersionRetriever
+	VersionsRetriever   contrats.LicenseVersionsRetriever
+	Amender             contrats.LicenseAmender
+	Importer            contrats.LicenseImporter
+	Exporter            contrats.LicenseExporter
+	ExportJobStarter    contrats.ExportJobStarter
+	ExportJobRetriever  contrats.ExportJobRetriever
+	ExportJobDownloader contrats.ExportJobDownloader
+}
+
+// importMaxBytes acota el archivo de POST /licenses/import; 0 no lo acota.
+func NewLicenseController(useCases LicenseUseCases, importMaxBytes int64) *LicenseController {
 	return &LicenseController{
-		issueLicenseUseCase:               issueLicenseUseCase,
-		retrieveLicenseUseCase:            retrieveLicenseUseCase,
-		licenseVerifierUseCase:            licenseVerifierUseCase,
-		licensesByPatientRetrieverUseCase: licensesByPatientRetrieverUseCase,
-		logger:                            *logs.NewLogger(),
+		issueLicenseUseCase:               useCases.Issuer,
+		retrieveLicenseUseCase:            useCases.Retriever,
+		licenseVerifierUseCase:            useCases.Verifier,
+		licenseBatchVerifierUseCase:       useCases.BatchVerifier,
+		licensesByPatientRetrieverUseCase: useCases.ByPatientRetriever,
+		licenseResolverUseCase:            useCases.Resolver,
+		licenseVersionRetrieverUseCase:    useCases.VersionRetriever,
+		licenseVersionsRetrieverUseCase:   useCases.VersionsRetriever,
+		licenseAmenderUseCase:             useCases.Amender,
+		licenseImporterUseCase:            useCases.Importer,
+		licenseExporterUseCase:            useCases.Exporter,
+		exportJobStarterUseCase:           useCases.ExportJobStarter,
+		exportJobRetrieverUseCase:         useCases.ExportJobRetriever,
+		exportJobDownloaderUseCase:        useCases.ExportJobDownloader,
+		importMaxBytes:                    importMaxBytes,
 	}
 }
 
 func (lc *LicenseController) CreateLicense(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		lc.logger.Error("LicenseController", "CreateLicense", nil, "method not allowed: "+r.Method)
+		logs.FromContext(r.Context()).Error("LicenseController", "CreateLicense", nil, "method not allowed: "+r.Method)
 		handler.WriteErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED")
 		return
 	}
@@ -52,7 +90,7 @@ func (lc *LicenseController) CreateLicense(w http.ResponseWriter, r *http.Reques
 			"CreateLicense",
 			"failed to decode request body",
 		)
-		lc.logger.Error("LicenseController", "CreateLicense", AppErr, "invalid JSON in request body")
+		logs.FromContext(r.Context()).Error("LicenseController", "CreateLicense", AppErr, "invalid JSON in request body")
 		handler.WriteErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST")
 		return
 	}
@@ -60,13 +98,13 @@ func (lc *LicenseController) CreateLicense(w http.ResponseWriter, r *http.Reques
 	ctx := r.Context()
 	license, err := lc.issueLicenseUseCase.Execute(ctx, req)
 	if err != nil {
-		lc.logger.Error("LicenseController", "CreateLicense", err, "use case execution failed")
+		logs.FromContext(r.Context()).Error("LicenseController", "CreateLicense", err, "use case execution failed")
 
-		lc.logger.Info("LicenseController", "CreateLicense", "Error message: "+err.Error())
+		logs.FromContext(r.Context()).Info("LicenseController", "CreateLicense", "Error message: "+err.Error())
 
 		switch {
 		case strings.Contains(err.Error(), "Days must be greater than 0"):
-			lc.logger.Info("LicenseController", "CreateLicense", "Matched invalid days case")
+			logs.FromContext(r.Context()).Info("LicenseController", "CreateLicense", "Matched invalid days case")
 			handler.WriteErrorResponse(w, http.StatusBadRequest, "INVALID_DAYS")
 			return
 		case strings.Contains(err.Error(), "invalid date"):
@@ -79,13 +117,14 @@ func (lc *LicenseController) CreateLicense(w http.ResponseWriter, r *http.Reques
 			handler.WriteErrorResponse(w, http.StatusBadRequest, "INVALID_DOCTOR")
 			return
 		default:
-			lc.logger.Info("LicenseController", "CreateLicense", "No specific error match, using default handler")
+			logs.FromContext(r.Context()).Info("LicenseController", "CreateLicense", "No specific error match, using default handler")
 			handler.HandleUseCaseError(w, err)
 			return
 		}
 	}
-	lc.logger.Info("LicenseController", "CreateLicense", "license created successfully")
+	logs.FromContext(r.Context()).Info("LicenseController", "CreateLicense", "license created successfully")
 
+	w.Header().Set("ETag", licenseETag(license.Revision))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 
@@ -96,14 +135,14 @@ func (lc *LicenseController) CreateLicense(w http.ResponseWriter, r *http.Reques
 			"CreateLicense",
 			"failed to encode response",
 		)
-		lc.logger.Error("LicenseController", "CreateLicense", AppErr, "response encoding failed")
+		logs.FromContext(r.Context()).Error("LicenseController", "CreateLicense", AppErr, "response encoding failed")
 		handler.WriteErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR")
 	}
 }
 
 func (lc *LicenseController) GetLicense(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		lc.logger.Error("LicenseController", "GetLicense", nil, "method not allowed: "+r.Method)
+		logs.FromContext(r.Context()).Error("LicenseController", "GetLicense", nil, "method not allowed: "+r.Method)
 		handler.WriteErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED")
 		return
 	}
@@ -112,23 +151,39 @@ func (lc *LicenseController) GetLicense(w http.ResponseWriter, r *http.Request)
 	folio := vars["folio"]
 
 	if folio == "" {
-		lc.logger.Error("LicenseController", "GetLicense", nil, "folio parameter is missing")
+		logs.FromContext(r.Context()).Error("LicenseController", "GetLicense", nil, "folio parameter is missing")
 		handler.WriteErrorResponse(w, http.StatusBadRequest, "NOT_FOUND")
 		return
 	}
 
-	lc.logger.Info("LicenseController", "GetLicense", "retrieving license with folio: "+folio)
+	logs.FromContext(r.Context()).Info("LicenseController", "GetLicense", "retrieving license with folio: "+folio)
 
 	ctx := r.Context()
-	license, err := lc.retrieveLicenseUseCase.Execute(ctx, folio)
+	var license *dto.LicenseDTO
+	var err error
+	if value := r.URL.Query().Get("version"); value != "" {
+		version, parseErr := strconv.Atoi(value)
+		if parseErr != nil {
+			logs.FromContext(r.Context()).Error("LicenseController", "GetLicense", parseErr, "invalid version parameter: "+value)
+			handler.WriteDetailedErrorResponse(w, http.StatusBadRequest, "INVALID_DATA", "version must be a positive integer")
+			return
+		}
+		license, err = lc.licenseVersionRetrieverUseCase.Execute(ctx, folio, version)
+	} else {
+		license, err = lc.retrieveLicenseUseCase.Execute(ctx, folio)
+	}
 	if err != nil {
-		lc.logger.Error("LicenseController", "GetLicense", err, "use case execution failed")
+		logs.FromContext(r.Context()).Error("LicenseController", "GetLicense", err, "use case execution failed")
 		handler.HandleUseCaseError(w, err)
 		return
 	}
 
-	lc.logger.Info("LicenseController", "GetLicense", "license retrieved successfully")
+	logs.FromContext(r.Context()).Info("LicenseController", "GetLicense", "license retrieved successfully")
 
+	// Una versión histórica no es el estado actual: no lleva ETag.
+	if r.URL.Query().Get("version") == "" {
+		w.Header().Set("ETag", licenseETag(license.Revision))
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
@@ -139,11 +194,15 @@ func (lc *LicenseController) GetLicense(w http.ResponseWriter, r *http.Request)
 			"GetLicense",
 			"failed to encode response",
 		)
-		lc.logger.Error("LicenseController", "GetLicense", AppErr, "response encoding failed")
+		logs.FromContext(r.Context()).Error("LicenseController", "GetLicense", AppErr, "response encoding failed")
 		handler.WriteErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR")
 	}
 }
 
+// VerifyLicense responde el resultado completo de la verificación: 200 para un
+// folio existente (sea o no válido) y 404 para uno inexistente. Con
+// ?compact=true mantiene la respuesta de los clientes antiguos: solo
+// {"valid":...}, con 404 para toda licencia no válida.
 func (controller *LicenseController) VerifyLicense(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	folio := vars["folio"]
@@ -157,52 +216,480 @@ func (controller *LicenseController) VerifyLicense(w http.ResponseWriter, r *htt
 		return
 	}
 
-	isValid, err := controller.licenseVerifierUseCase.Execute(r.Context(), folio)
+	compact := false
+	if value := r.URL.Query().Get("compact"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			logs.FromContext(r.Context()).Error("LicenseController", "VerifyLicense", err, "invalid compact parameter: "+value)
+			handler.WriteDetailedErrorResponse(w, http.StatusBadRequest, "INVALID_DATA", "compact must be true or false")
+			return
+		}
+		compact = parsed
+	}
+
+	verification, err := controller.licenseVerifierUseCase.Execute(r.Context(), folio)
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error": "Internal server error",
-		})
+		logs.FromContext(r.Context()).Error("LicenseController", "VerifyLicense", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 
-	if isValid {
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(map[string]bool{"valid": true})
-	} else {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(map[string]bool{"valid": false})
+	if compact {
+		status := http.StatusOK
+		if !verification.Valid {
+			status = http.StatusNotFound
+		}
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]bool{"valid": verification.Valid})
+		return
+	}
+
+	status := http.StatusOK
+	if !verification.Found() {
+		status = http.StatusNotFound
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(verification)
+}
+
+// VerifyLicenses responde 200 aunque algunos ítems fallen: cada uno trae su
+// verificación o su error.
+func (lc *LicenseController) VerifyLicenses(w http.ResponseWriter, r *http.Request) {
+	var req dto.BatchVerificationRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInvalidData,
+			"LicenseController",
+			"VerifyLicenses",
+			"failed to decode request body",
+		)
+		logs.FromContext(r.Context()).Error("LicenseController", "VerifyLicenses", AppErr, "invalid JSON in request body")
+		handler.WriteErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	verifications, err := lc.licenseBatchVerifierUseCase.Execute(r.Context(), req)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("LicenseController", "VerifyLicenses", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(verifications); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInternalError,
+			"LicenseController",
+			"VerifyLicenses",
+			"failed to encode response",
+		)
+		logs.FromContext(r.Context()).Error("LicenseController", "VerifyLicenses", AppErr, "response encoding failed")
+	}
+}
+
+// ImportLicenses lee el archivo directamente del body. El formato sale de
+// ?format= o, si falta, del Content-Type (text/csv o application/x-ndjson).
+func (lc *LicenseController) ImportLicenses(w http.ResponseWriter, r *http.Request) {
+	options := dto.ImportOptionsDTO{Format: r.URL.Query().Get("format")}
+	if options.Format == "" {
+		options.Format = importFormatFromContentType(r.Header.Get("Content-Type"))
+	}
+
+	if value := r.URL.Query().Get("dryRun"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			logs.FromContext(r.Context()).Error("LicenseController", "ImportLicenses", err, "invalid dryRun parameter: "+value)
+			handler.WriteDetailedErrorResponse(w, http.StatusBadRequest, "INVALID_DATA", "dryRun must be true or false")
+			return
+		}
+		options.DryRun = dryRun
+	}
+
+	// Subir y guardar un archivo grande puede durar más que los timeouts del
+	// servidor; el tamaño lo acota importMaxBytes.
+	controller := http.NewResponseController(w)
+	_ = controller.SetReadDeadline(time.Time{})
+	_ = controller.SetWriteDeadline(time.Time{})
+	body := r.Body
+	if lc.importMaxBytes > 0 {
+		body = http.MaxBytesReader(w, r.Body, lc.importMaxBytes)
+	}
+
+	report, err := lc.licenseImporterUseCase.Execute(r.Context(), body, options)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("LicenseController", "ImportLicenses", err, "use case execution failed")
+		if report == nil {
+			handler.HandleUseCaseError(w, err)
+			return
+		}
+		// La importación se cortó a medias: el informe dice qué alcanzó a
+		// guardarse.
+		writeImportReport(w, r, interruptedImportStatus(err), report)
+		return
+	}
+
+	status := http.StatusOK
+	if report.Created > 0 {
+		status = http.StatusCreated
+	}
+	writeImportReport(w, r, status, report)
+}
+
+// interruptedImportStatus responde 413 si el archivo superó importMaxBytes y
+// si no el estado del código de error.
+func interruptedImportStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if stderrors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	if appErr, ok := err.(*errors.AppError); ok {
+		return appErr.HTTPStatus()
+	}
+	return http.StatusInternalServerError
+}
+
+func writeImportReport(w http.ResponseWriter, r *http.Request, status int, report *dto.ImportReportDTO) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInternalError,
+			"LicenseController",
+			"ImportLicenses",
+			"failed to encode response",
+		)
+		logs.FromContext(r.Context()).Error("LicenseController", "ImportLicenses", AppErr, "response encoding failed")
+	}
+}
+
+func importFormatFromContentType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	switch strings.TrimSpace(strings.ToLower(mediaType)) {
+	case "text/csv":
+		return dto.ImportFormatCSV
+	case "application/x-ndjson", "application/jsonl":
+		return dto.ImportFormatNDJSON
+	}
+	return ""
+}
+
+// ExportLicenses acepta los filtros de GET /licenses más format, columns
+// (separadas por coma) y pseudonymize. Sin async el archivo se escribe en la
+// respuesta a medida que se lee; con async=true responde 202 con el trabajo,
+// que se consulta en su Location.
+func (lc *LicenseController) ExportLicenses(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := dto.ExportRequestDTO{
+		Filter: dto.LicenseFilterDTO{
+			PatientID: query.Get("patientId"),
+			Type:      query.Get("type"),
+		},
+		Format: strings.ToLower(query.Get("format")),
+	}
+	if request.Format == "" {
+		request.Format = dto.ExportFormatCSV
+	}
+	if value := query.Get("columns"); value != "" {
+		for _, column := range strings.Split(value, ",") {
+			request.Columns = append(request.Columns, strings.TrimSpace(column))
+		}
+	}
+
+	pseudonymize, ok := boolQueryParam(w, r, "ExportLicenses", "pseudonymize")
+	if !ok {
+		return
+	}
+	request.PseudonymizeDiagnosis = pseudonymize
+	async, ok := boolQueryParam(w, r, "ExportLicenses", "async")
+	if !ok {
+		return
+	}
+
+	if async {
+		lc.startExportJob(w, r, request)
+		return
+	}
+
+	// Una exportación grande puede durar más que el WriteTimeout del servidor.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	output := &exportResponseWriter{
+		w:           w,
+		name:        "licenses." + request.Format,
+		contentType: dto.ExportContentType(request.Format),
+	}
+	rows, err := lc.licenseExporterUseCase.Execute(r.Context(), request, output)
+	if err != nil {
+		if !output.started {
+			logs.FromContext(r.Context()).Error("LicenseController", "ExportLicenses", err, "use case execution failed")
+			handler.HandleUseCaseError(w, err)
+			return
+		}
+		// El 200 ya salió: cortar la conexión es la única forma de que el
+		// cliente no tome el archivo parcial como completo.
+		logs.FromContext(r.Context()).Error("LicenseController", "ExportLicenses", err, "export aborted after streaming started")
+		panic(http.ErrAbortHandler)
+	}
+	output.start()
+
+	logs.FromContext(r.Context()).Info("LicenseController", "ExportLicenses", "licenses exported successfully", "count", rows)
+}
+
+func (lc *LicenseController) startExportJob(w http.ResponseWriter, r *http.Request, request dto.ExportRequestDTO) {
+	job, err := lc.exportJobStarterUseCase.Execute(r.Context(), request)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("LicenseController", "ExportLicenses", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", job.Links.Self)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInternalError,
+			"LicenseController",
+			"ExportLicenses",
+			"failed to encode response",
+		)
+		logs.FromContext(r.Context()).Error("LicenseController", "ExportLicenses", AppErr, "response encoding failed")
+	}
+}
+
+func (lc *LicenseController) GetExportJob(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	job, err := lc.exportJobRetrieverUseCase.Execute(r.Context(), id)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("LicenseController", "GetExportJob", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInternalError,
+			"LicenseController",
+			"GetExportJob",
+			"failed to encode response",
+		)
+		logs.FromContext(r.Context()).Error("LicenseController", "GetExportJob", AppErr, "response encoding failed")
+	}
+}
+
+func (lc *LicenseController) DownloadExportJob(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	file, err := lc.exportJobDownloaderUseCase.Execute(r.Context(), id)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("LicenseController", "DownloadExportJob", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+	defer file.Content.Close()
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+file.Name+`"`)
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, file.Content); err != nil {
+		logs.FromContext(r.Context()).Error("LicenseController", "DownloadExportJob", err, "export download interrupted")
+	}
+}
+
+// boolQueryParam lee un parámetro booleano opcional; si es inválido responde
+// 400 y devuelve ok en false.
+func boolQueryParam(w http.ResponseWriter, r *http.Request, operation, name string) (value bool, ok bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, true
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("LicenseController", operation, err, "invalid "+name+" parameter: "+raw)
+		handler.WriteDetailedErrorResponse(w, http.StatusBadRequest, "INVALID_DATA", name+" must be true or false")
+		return false, false
+	}
+	return value, true
+}
+
+// exportResponseWriter envía los encabezados y el 200 recién con la primera
+// escritura, así un error de validación todavía puede responderse como tal.
+type exportResponseWriter struct {
+	w           http.ResponseWriter
+	name        string
+	contentType string
+	started     bool
+}
+
+func (o *exportResponseWriter) Write(p []byte) (int, error) {
+	o.start()
+	return o.w.Write(p)
+}
+
+func (o *exportResponseWriter) start() {
+	if o.started {
+		return
+	}
+	o.started = true
+	o.w.Header().Set("Content-Type", o.contentType)
+	o.w.Header().Set("Content-Disposition", `attachment; filename="`+o.name+`"`)
+	o.w.WriteHeader(http.StatusOK)
+}
+
+func (lc *LicenseController) GetLicenseVersions(w http.ResponseWriter, r *http.Request) {
+	folio := mux.Vars(r)["folio"]
+
+	versions, err := lc.licenseVersionsRetrieverUseCase.Execute(r.Context(), folio)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("LicenseController", "GetLicenseVersions", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(versions); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInternalError,
+			"LicenseController",
+			"GetLicenseVersions",
+			"failed to encode response",
+		)
+		logs.FromContext(r.Context()).Error("LicenseController", "GetLicenseVersions", AppErr, "response encoding failed")
+	}
+}
+
+func (lc *LicenseController) AmendLicense(w http.ResponseWriter, r *http.Request) {
+	folio := mux.Vars(r)["folio"]
+
+	revision, ok := ifMatchRevision(w, r, "LicenseController", "AmendLicense")
+	if !ok {
+		return
+	}
+
+	var req dto.AmendLicenseDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInvalidData,
+			"LicenseController",
+			"AmendLicense",
+			"failed to decode request body",
+		)
+		logs.FromContext(r.Context()).Error("LicenseController", "AmendLicense", AppErr, "invalid JSON in request body")
+		handler.WriteErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	license, err := lc.licenseAmenderUseCase.Execute(r.Context(), folio, req, revision)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("LicenseController", "AmendLicense", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	logs.FromContext(r.Context()).Info("LicenseController", "AmendLicense", "license amended successfully")
+
+	w.Header().Set("ETag", licenseETag(license.Revision))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(license); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInternalError,
+			"LicenseController",
+			"AmendLicense",
+			"failed to encode response",
+		)
+		logs.FromContext(r.Context()).Error("LicenseController", "AmendLicense", AppErr, "response encoding failed")
+	}
+}
+
+func (lc *LicenseController) ResolveLicense(w http.ResponseWriter, r *http.Request) {
+	folio := mux.Vars(r)["folio"]
+
+	revision, ok := ifMatchRevision(w, r, "LicenseController", "ResolveLicense")
+	if !ok {
+		return
+	}
+
+	var req dto.ResolutionDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInvalidData,
+			"LicenseController",
+			"ResolveLicense",
+			"failed to decode request body",
+		)
+		logs.FromContext(r.Context()).Error("LicenseController", "ResolveLicense", AppErr, "invalid JSON in request body")
+		handler.WriteErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST")
+		return
+	}
+
+	license, err := lc.licenseResolverUseCase.Execute(r.Context(), folio, req, revision)
+	if err != nil {
+		logs.FromContext(r.Context()).Error("LicenseController", "ResolveLicense", err, "use case execution failed")
+		handler.HandleUseCaseError(w, err)
+		return
+	}
+
+	logs.FromContext(r.Context()).Info("LicenseController", "ResolveLicense", "license resolved successfully")
+
+	w.Header().Set("ETag", licenseETag(license.Revision))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(license); err != nil {
+		AppErr := errors.NewAppError(
+			errors.ErrInternalError,
+			"LicenseController",
+			"ResolveLicense",
+			"failed to encode response",
+		)
+		logs.FromContext(r.Context()).Error("LicenseController", "ResolveLicense", AppErr, "response encoding failed")
 	}
 }
 
 func (lc *LicenseController) GetLicensesByPatient(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		lc.logger.Error("LicenseController", "GetLicensesByPatient", nil, "method not allowed: "+r.Method)
+		logs.FromContext(r.Context()).Error("LicenseController", "GetLicensesByPatient", nil, "method not allowed: "+r.Method)
 		handler.WriteErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED")
 		return
 	}
 
 	patientID := r.URL.Query().Get("patientId")
 	if patientID == "" {
-		lc.logger.Error("LicenseController", "GetLicensesByPatient", nil, "patientId parameter is missing")
+		logs.FromContext(r.Context()).Error("LicenseController", "GetLicensesByPatient", nil, "patientId parameter is missing")
 		handler.WriteErrorResponse(w, http.StatusBadRequest, "MISSING_REQUIRED_FIELD")
 		return
 	}
 
-	lc.logger.Info("LicenseController", "GetLicensesByPatient", "retrieving licenses for patient: "+patientID)
+	logs.FromContext(r.Context()).Info("LicenseController", "GetLicensesByPatient", "retrieving licenses for patient: "+patientID)
 
 	ctx := r.Context()
-	licenses, err := lc.licensesByPatientRetrieverUseCase.Execute(ctx, patientID)
+	licenses, err := lc.licensesByPatientRetrieverUseCase.Execute(ctx, dto.LicenseFilterDTO{
+		PatientID: patientID,
+		Type:      r.URL.Query().Get("type"),
+	})
 	if err != nil {
-		lc.logger.Error("LicenseController", "GetLicensesByPatient", err, "use case execution failed")
+		logs.FromContext(r.Context()).Error("LicenseController", "GetLicensesByPatient", err, "use case execution failed")
 		handler.HandleUseCaseError(w, err)
 		return
 	}
 
-	lc.logger.Info("LicenseController", "GetLicensesByPatient", "licenses retrieved successfully", "count", len(licenses))
+	logs.FromContext(r.Context()).Info("LicenseController", "GetLicensesByPatient", "licenses retrieved successfully", "count", len(licenses))
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -214,7 +701,7 @@ func (lc *LicenseController) GetLicensesByPatient(w http.ResponseWriter, r *http
 			"GetLicensesByPatient",
 			"failed to encode response",
 		)
-		lc.logger.Error("LicenseController", "GetLicensesByPatient", AppErr, "response encoding failed")
+		logs.FromContext(r.Context()).Error("LicenseController", "GetLicensesByPatient", AppErr, "response encoding failed")
 		handler.WriteErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR")
 	}
 }
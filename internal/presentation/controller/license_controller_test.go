@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"license-service/internal/application/dto"
+	errors "license-service/pkg/log/error"
+)
+
+// readingImporter lee todo el archivo y, si la lectura falla, devuelve un
+// informe parcial junto con el error, como LicenseImportUseCase.
+type readingImporter struct{}
+
+func (readingImporter) Execute(_ context.Context, input io.Reader, _ dto.ImportOptionsDTO) (*dto.ImportReportDTO, error) {
+	if _, err := io.ReadAll(input); err != nil {
+		appErr := errors.WrapError(errors.ErrInvalidData, "Test", "Execute", "import file could not be read", err)
+		return &dto.ImportReportDTO{Interrupted: true, Rows: []dto.ImportRowResultDTO{}}, appErr
+	}
+	return &dto.ImportReportDTO{Created: 1, Rows: []dto.ImportRowResultDTO{{Row: 1, Status: dto.ImportRowCreated}}}, nil
+}
+
+func TestImportLicensesLimitsBody(t *testing.T) {
+	controller := NewLicenseController(LicenseUseCases{Importer: readingImporter{}}, 16)
+	tests := []struct {
+		name            string
+		body            string
+		wantStatus      int
+		wantInterrupted bool
+	}{
+		{"within limit", "short", http.StatusCreated, false},
+		{"over limit", strings.Repeat("x", 17), http.StatusRequestEntityTooLarge, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/licenses/import?format=ndjson", strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			controller.ImportLicenses(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			var report dto.ImportReportDTO
+			if err := json.NewDecoder(recorder.Body).Decode(&report); err != nil {
+				t.Fatalf("body is not an import report: %v", err)
+			}
+			if report.Interrupted != tt.wantInterrupted {
+				t.Errorf("interrupted = %v, want %v", report.Interrupted, tt.wantInterrupted)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"license-service/pkg/handler"
+	logs "license-service/pkg/log/logger"
+)
+
+// licenseETag es el ETag fuerte de una licencia: su revisión entre comillas.
+func licenseETag(revision int) string {
+	return strconv.Quote(strconv.Itoa(revision))
+}
+
+// ifMatchRevision lee la revisión que exige el If-Match de una escritura. Sin
+// el header responde 428; con un ETag que no es de licencia, 412 (nunca podría
+// coincidir). "*" acepta cualquier revisión y devuelve 0.
+func ifMatchRevision(w http.ResponseWriter, r *http.Request, component, operation string) (int, bool) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		logs.FromContext(r.Context()).Error(component, operation, nil, "missing If-Match header")
+		handler.WriteDetailedErrorResponse(w, http.StatusPreconditionRequired, "PRECONDITION_REQUIRED", "If-Match header with the license ETag is required")
+		return 0, false
+	}
+	if value == "*" {
+		return 0, true
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	revision, atoiErr := strconv.Atoi(unquoted)
+	if err != nil || atoiErr != nil || revision < 1 {
+		logs.FromContext(r.Context()).Error(component, operation, nil, "malformed If-Match header: "+value)
+		handler.WriteDetailedErrorResponse(w, http.StatusPreconditionFailed, "PRECONDITION_FAILED", "If-Match does not match the license ETag")
+		return 0, false
+	}
+	return revision, true
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"license-service/internal/application/usecase/contrats"
+	"license-service/pkg/auth"
+	handler "license-service/pkg/handler"
+	errors "license-service/pkg/log/error"
+	logs "license-service/pkg/log/logger"
+
+	"github.com/gorilla/mux"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// Authentication acepta una API key en X-API-Key (integraciones máquina a
+// máquina) o un JWT en Authorization: Bearer. La API key se valida contra el
+// nombre de la ruta de mux, que actúa como scope.
+func Authentication(validator *auth.TokenValidator, apiKeys contrats.APIKeyAuthenticator) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" && apiKeys != nil {
+				principal, err := apiKeys.Execute(r.Context(), apiKey, RouteName(r))
+				if err != nil {
+					logs.FromContext(r.Context()).Error("AuthenticationMiddleware", "Authentication", err, "api key rejected")
+					if errors.IsAppErrorCode(err, string(errors.ErrUnauthorized)) {
+						writeUnauthorized(w)
+						return
+					}
+					// Una falla al buscar la clave no es una credencial inválida:
+					// con 401 el cliente descartaría una clave buena.
+					handler.HandleUseCaseError(w, err)
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+				return
+			}
+
+			header := r.Header.Get("Authorization")
+			scheme, token, found := strings.Cut(header, " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+				logs.FromContext(r.Context()).Info("AuthenticationMiddleware", "Authentication", "missing credentials")
+				writeUnauthorized(w)
+				return
+			}
+
+			principal, err := validator.Validate(r.Context(), strings.TrimSpace(token))
+			if err != nil {
+				logs.FromContext(r.Context()).Error("AuthenticationMiddleware", "Authentication", err, "token rejected")
+				writeUnauthorized(w)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// RequireRole corta la petición con 403 si el principal autenticado no tiene el
+// rol indicado, y con 401 si no hay principal: con la autenticación
+// deshabilitada las rutas protegidas quedan cerradas, no abiertas.
+func RequireRole(role string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := auth.PrincipalFromContext(r.Context())
+			if !ok {
+				logs.FromContext(r.Context()).Warn("AuthenticationMiddleware", "RequireRole", "request without principal on a route that requires role "+role)
+				writeUnauthorized(w)
+				return
+			}
+			if !principal.HasRole(role) {
+				handler.WriteErrorResponse(w, http.StatusForbidden, "FORBIDDEN")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func RouteName(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		return route.GetName()
+	}
+	return ""
+}
+
+func writeUnauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="licenses"`)
+	handler.WriteErrorResponse(w, http.StatusUnauthorized, "UNAUTHORIZED")
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"license-service/pkg/metrics"
+
+	"github.com/gorilla/mux"
+)
+
+// Metrics registra conteo y latencia por plantilla de ruta (/licenses/{folio}) y no
+// por URL concreta, para no crear una serie por folio.
+func Metrics() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := newStatusRecorder(w)
+
+			next.ServeHTTP(recorder, r)
+
+			labels := []string{RouteTemplate(r), r.Method, strconv.Itoa(recorder.status)}
+			metrics.HTTPRequestsTotal.WithLabelValues(labels...).Inc()
+			metrics.HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+func RouteTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return "unmatched"
+}
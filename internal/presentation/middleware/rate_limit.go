@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"license-service/pkg/auth"
+	handler "license-service/pkg/handler"
+	errors "license-service/pkg/log/error"
+	logs "license-service/pkg/log/logger"
+	"license-service/pkg/ratelimit"
+
+	"github.com/gorilla/mux"
+)
+
+// RateLimit aplica un token bucket por cliente y por ruta. El cliente es la API
+// key, el subject del JWT o, sin autenticación, la IP remota; por eso debe
+// registrarse después de Authentication.
+func RateLimit(store ratelimit.Store, routeLimits map[string]ratelimit.Limit, defaultLimit ratelimit.Limit) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := RouteName(r)
+			limit, ok := routeLimits[route]
+			if !ok {
+				limit = defaultLimit
+			}
+
+			result, err := store.Take(r.Context(), route+"|"+clientKey(r), limit)
+			if err != nil {
+				logs.FromContext(r.Context()).Error("RateLimitMiddleware", "RateLimit", err, "rate limit store failed, allowing request")
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				logs.FromContext(r.Context()).Info("RateLimitMiddleware", "RateLimit", "rate limit exceeded on "+route)
+				handler.WriteErrorResponse(w, http.StatusTooManyRequests, string(errors.ErrTooManyRequests))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func clientKey(r *http.Request) string {
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		if principal.APIKey != "" {
+			return "apikey:" + principal.APIKey
+		}
+		return "sub:" + principal.Subject
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"time"
+
+	logs "license-service/pkg/log/logger"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID reutiliza el X-Request-ID entrante (si es seguro para logs) o genera
+// uno, lo devuelve en la respuesta y deja en el contexto un logger que lo incluye
+// en cada entrada.
+func RequestID() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(RequestIDHeader)
+			if !validRequestID.MatchString(requestID) {
+				requestID = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, requestID)
+
+			trace.SpanFromContext(r.Context()).SetAttributes(attribute.String("http.request_id", requestID))
+
+			entry := logs.FromContext(r.Context()).WithFields(logrus.Fields{
+				"request_id": requestID,
+				"method":     r.Method,
+				"route":      RouteTemplate(r),
+			})
+			next.ServeHTTP(w, r.WithContext(logs.NewContext(r.Context(), entry)))
+		})
+	}
+}
+
+// AccessLog escribe una línea por petición con status y duración usando el logger
+// de la petición.
+func AccessLog() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := newStatusRecorder(w)
+
+			next.ServeHTTP(recorder, r)
+
+			logs.FromContext(r.Context()).WithFields(logrus.Fields{
+				"status":      recorder.status,
+				"duration_ms": time.Since(start).Milliseconds(),
+				"path":        r.URL.Path,
+			}).Info("request completed")
+		})
+	}
+}
+
+func newRequestID() string {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(raw)
+}
@@ -1,33 +1,136 @@
 package router
 
 import (
-	"license-service/internal/application/usecase/contrats"
 	"license-service/internal/presentation/controller"
-	logs "license-service/pkg/log/logger"
+	"license-service/internal/presentation/middleware"
+	"license-service/pkg/auth"
+	"license-service/pkg/health"
+	"license-service/pkg/metrics"
 
 	"github.com/gorilla/mux"
 )
 
-func SetupRoutes(
-	licenseIssuer contrats.LicenseIssuer,
-	licenseRetriever contrats.LicenseRetriever,
-	licenseVerifier contrats.LicenseVerifier,
-	licensesByPatientRetriever contrats.LicensesByPatientRetriever,
-	logger logs.Logger,
-) *mux.Router {
+// Los nombres de ruta se usan como scopes de las API keys.
+const (
+	RouteLicensesCreate              = "licenses.create"
+	RouteLicensesList                = "licenses.list"
+	RouteLicensesGet                 = "licenses.get"
+	RouteLicensesVerify              = "licenses.verify"
+	RouteLicensesVerifyBatch         = "licenses.verify.batch"
+	RouteLicensesImport              = "licenses.import"
+	RouteLicensesExport              = "licenses.export"
+	RouteLicensesExportJobsGet       = "licenses.export.jobs.get"
+	RouteLicensesExportJobsDownload  = "licenses.export.jobs.download"
+	RouteLicensesResolve             = "licenses.resolve"
+	RouteLicensesAmend               = "licenses.amend"
+	RouteLicensesVersions            = "licenses.versions"
+	RouteEmployerLicensesList        = "employers.licenses.list"
+	RouteEmployerLicensesAcknowledge = "employers.licenses.acknowledge"
+	RouteAPIKeysCreate               = "admin.api-keys.create"
+	RouteAPIKeysList                 = "admin.api-keys.list"
+	RouteAPIKeysRotate               = "admin.api-keys.rotate"
+	RouteAPIKeysRevoke               = "admin.api-keys.revoke"
+)
+
+// RouteNames son los scopes que puede llevar una API key, además de "*".
+var RouteNames = []string{
+	RouteLicensesCreate,
+	RouteLicensesList,
+	RouteLicensesGet,
+	RouteLicensesVerify,
+	RouteLicensesVerifyBatch,
+	RouteLicensesImport,
+	RouteLicensesExport,
+	RouteLicensesExportJobsGet,
+	RouteLicensesExportJobsDownload,
+	RouteLicensesResolve,
+	RouteLicensesAmend,
+	RouteLicensesVersions,
+	RouteEmployerLicensesList,
+	RouteEmployerLicensesAcknowledge,
+	RouteAPIKeysCreate,
+	RouteAPIKeysList,
+	RouteAPIKeysRotate,
+	RouteAPIKeysRevoke,
+}
+
+// Dependencies reúne todo lo que necesita el router. Los middlewares nil se omiten.
+type Dependencies struct {
+	Licenses       controller.LicenseUseCases
+	APIKeys        controller.APIKeyUseCases
+	Employers      controller.EmployerUseCases
+	Authentication mux.MiddlewareFunc
+	RateLimit      mux.MiddlewareFunc
+	Health         *health.Registry
+	// ImportMaxBytes acota el cuerpo de POST /licenses/import.
+	ImportMaxBytes int64
+}
+
+func SetupRoutes(deps Dependencies) *mux.Router {
 	router := mux.NewRouter()
+	router.Use(middleware.Tracing())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Metrics())
+	router.Use(middleware.AccessLog())
+
+	healthController := controller.NewHealthController(deps.Health)
+
+	router.HandleFunc("/healthz", healthController.Liveness).Methods("GET")
+	router.HandleFunc("/readyz", healthController.Readiness).Methods("GET")
+	router.HandleFunc("/health/details", healthController.Details).Methods("GET")
+	router.Handle("/metrics", metrics.Handler()).Methods("GET")
+
+	licenseController := controller.NewLicenseController(deps.Licenses, deps.ImportMaxBytes)
+
+	apiKeyController := controller.NewAPIKeyController(deps.APIKeys)
+
+	employerController := controller.NewEmployerController(deps.Employers)
+
+	licenses := router.PathPrefix("/licenses").Subrouter()
+	if deps.Authentication != nil {
+		licenses.Use(deps.Authentication)
+	}
+	if deps.RateLimit != nil {
+		licenses.Use(deps.RateLimit)
+	}
+
+	licenses.HandleFunc("", licenseController.CreateLicense).Methods("POST").Name(RouteLicensesCreate)
+	licenses.HandleFunc("", licenseController.GetLicensesByPatient).Methods("GET").Name(RouteLicensesList)
+	licenses.HandleFunc("/verify:batch", licenseController.VerifyLicenses).Methods("POST").Name(RouteLicensesVerifyBatch)
+	licenses.HandleFunc("/import", licenseController.ImportLicenses).Methods("POST").Name(RouteLicensesImport)
+	licenses.HandleFunc("/export", licenseController.ExportLicenses).Methods("GET").Name(RouteLicensesExport)
+	licenses.HandleFunc("/export/jobs/{id}", licenseController.GetExportJob).Methods("GET").Name(RouteLicensesExportJobsGet)
+	licenses.HandleFunc("/export/jobs/{id}/download", licenseController.DownloadExportJob).Methods("GET").Name(RouteLicensesExportJobsDownload)
+	licenses.HandleFunc("/{folio}", licenseController.GetLicense).Methods("GET").Name(RouteLicensesGet)
+	licenses.HandleFunc("/{folio}/verify", licenseController.VerifyLicense).Methods("GET").Name(RouteLicensesVerify)
+	licenses.HandleFunc("/{folio}/versions", licenseController.GetLicenseVersions).Methods("GET").Name(RouteLicensesVersions)
+	licenses.HandleFunc("/{folio}/amendments", licenseController.AmendLicense).Methods("POST").Name(RouteLicensesAmend)
+	licenses.HandleFunc("/{folio}/resolution", licenseController.ResolveLicense).Methods("POST").Name(RouteLicensesResolve)
+
+	employers := router.PathPrefix("/employers").Subrouter()
+	if deps.Authentication != nil {
+		employers.Use(deps.Authentication)
+	}
+	if deps.RateLimit != nil {
+		employers.Use(deps.RateLimit)
+	}
+
+	employers.HandleFunc("/{rut}/licenses", employerController.GetEmployerLicenses).Methods("GET").Name(RouteEmployerLicensesList)
+	employers.HandleFunc("/{rut}/licenses/{folio}/acknowledgment", employerController.AcknowledgeLicense).Methods("POST").Name(RouteEmployerLicensesAcknowledge)
+
+	admin := router.PathPrefix("/admin").Subrouter()
+	if deps.Authentication != nil {
+		admin.Use(deps.Authentication)
+	}
+	admin.Use(middleware.RequireRole(auth.RoleAdmin))
+	if deps.RateLimit != nil {
+		admin.Use(deps.RateLimit)
+	}
 
-	licenseController := controller.NewLicenseController(
-		licenseIssuer,
-		licenseRetriever,
-		licenseVerifier,
-		licensesByPatientRetriever,
-	)
-
-	router.HandleFunc("/licenses", licenseController.CreateLicense).Methods("POST")
-	router.HandleFunc("/licenses", licenseController.GetLicensesByPatient).Methods("GET")
-	router.HandleFunc("/licenses/{folio}", licenseController.GetLicense).Methods("GET")
-	router.HandleFunc("/licenses/{folio}/verify", licenseController.VerifyLicense).Methods("GET")
+	admin.HandleFunc("/api-keys", apiKeyController.CreateAPIKey).Methods("POST").Name(RouteAPIKeysCreate)
+	admin.HandleFunc("/api-keys", apiKeyController.ListAPIKeys).Methods("GET").Name(RouteAPIKeysList)
+	admin.HandleFunc("/api-keys/{prefix}/rotate", apiKeyController.RotateAPIKey).Methods("POST").Name(RouteAPIKeysRotate)
+	admin.HandleFunc("/api-keys/{prefix}", apiKeyController.RevokeAPIKey).Methods("DELETE").Name(RouteAPIKeysRevoke)
 
 	return router
 }
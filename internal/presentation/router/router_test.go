@@ -0,0 +1,36 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// Las API keys solo aceptan scopes de RouteNames: una ruta con nombre que no
+// figure ahí no podría habilitarse con ninguna key salvo "*".
+func TestRouteNamesCoverNamedRoutes(t *testing.T) {
+	known := make(map[string]bool, len(RouteNames))
+	for _, name := range RouteNames {
+		known[name] = true
+	}
+
+	registered := make(map[string]bool)
+	err := SetupRoutes(Dependencies{}).Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if name := route.GetName(); name != "" {
+			registered[name] = true
+			if !known[name] {
+				t.Errorf("route %q is missing from RouteNames", name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range RouteNames {
+		if !registered[name] {
+			t.Errorf("RouteNames lists %q but no route has that name", name)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package server
+
+import (
+	"net"
+	"net/http"
+
+	env "license-service/pkg/env"
+)
+
+func NewHTTPServer(config env.ServerConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              net.JoinHostPort(config.Host, config.Port),
+		Handler:           handler,
+		ReadTimeout:       config.ReadTimeout,
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+		WriteTimeout:      config.WriteTimeout,
+		IdleTimeout:       config.IdleTimeout,
+		MaxHeaderBytes:    config.MaxHeaderBytes,
+	}
+}
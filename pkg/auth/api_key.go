@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	MethodAPIKey = "api_key"
+
+	apiKeyPrefix = "lsk"
+)
+
+// GenerateAPIKey crea una clave con formato lsk_<prefijo>_<secreto>. El prefijo
+// identifica la clave en logs y endpoints de administración; solo el hash de la
+// clave completa se guarda en la base de datos.
+func GenerateAPIKey() (plaintext string, prefix string, hash string, err error) {
+	prefixBytes := make([]byte, 6)
+	if _, err = rand.Read(prefixBytes); err != nil {
+		return "", "", "", err
+	}
+	secretBytes := make([]byte, 32)
+	if _, err = rand.Read(secretBytes); err != nil {
+		return "", "", "", err
+	}
+
+	prefix = hex.EncodeToString(prefixBytes)
+	plaintext = apiKeyPrefix + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(secretBytes)
+	return plaintext, prefix, HashAPIKey(plaintext), nil
+}
+
+func HashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+func ParseAPIKeyPrefix(plaintext string) (string, bool) {
+	parts := strings.SplitN(plaintext, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func APIKeyMatches(plaintext, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(plaintext)), []byte(hash)) == 1
+}
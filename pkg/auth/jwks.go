@@ -0,0 +1,245 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	errorInfo "license-service/pkg/log/error"
+	"license-service/pkg/telemetry"
+)
+
+// KeySource entrega la clave pública asociada a un "kid" del header JWT.
+type KeySource interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type staticKeySource struct {
+	keys map[string]crypto.PublicKey
+}
+
+func NewFileKeySource(path string) (KeySource, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorInfo.WrapError(errorInfo.ErrConfigLoadFailed, "JWKS", "NewFileKeySource", "failed to read JWKS file", err)
+	}
+
+	keys, err := parseKeySet(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &staticKeySource{keys: keys}, nil
+}
+
+func (s *staticKeySource) Key(_ context.Context, kid string) (crypto.PublicKey, error) {
+	return lookupKey(s.keys, kid)
+}
+
+// remoteKeySource descarga el JWKS bajo demanda y lo vuelve a pedir cuando expira
+// o aparece un kid desconocido (rotación de claves), sin intentarlo más de una
+// vez por minRefresh aunque la descarga falle. La descarga corre fuera del lock
+// y una sola a la vez: mientras tanto se siguen usando las claves en caché, y
+// solo espera quien no tiene todavía la clave que necesita.
+type remoteKeySource struct {
+	url        string
+	client     *http.Client
+	ttl        time.Duration
+	minRefresh time.Duration
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time
+	lastAttempt time.Time
+	lastErr     error
+	// refreshing se cierra al terminar la descarga en curso; nil si no hay una.
+	refreshing chan struct{}
+}
+
+func NewRemoteKeySource(url string, client *http.Client) KeySource {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second, Transport: telemetry.NewHTTPTransport(nil)}
+	}
+	return &remoteKeySource{
+		url:        url,
+		client:     client,
+		ttl:        15 * time.Minute,
+		minRefresh: 30 * time.Second,
+	}
+}
+
+func (s *remoteKeySource) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	_, known := s.keys[kid]
+	stale := time.Since(s.fetchedAt) > s.ttl
+	if (!known || stale) && time.Since(s.lastAttempt) > s.minRefresh {
+		s.startRefresh(ctx)
+	}
+	done := s.refreshing
+	s.mu.Unlock()
+
+	if done != nil && !known {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, errorInfo.WrapError(errorInfo.ErrExternalServiceUnavailable, "JWKS", "Key", "JWKS download did not finish", ctx.Err())
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys == nil {
+		return nil, s.lastErr
+	}
+	return lookupKey(s.keys, kid)
+}
+
+// startRefresh lanza la descarga si no hay otra en curso. Se llama con s.mu
+// tomado. La descarga no se corta si el pedido que la lanzó termina antes.
+func (s *remoteKeySource) startRefresh(ctx context.Context) {
+	if s.refreshing != nil {
+		return
+	}
+	done := make(chan struct{})
+	s.refreshing = done
+	s.lastAttempt = time.Now()
+
+	go func() {
+		defer close(done)
+		keys, err := s.fetch(context.WithoutCancel(ctx))
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.refreshing = nil
+		if err != nil {
+			s.lastErr = err
+			return
+		}
+		s.keys = keys
+		s.fetchedAt = time.Now()
+		s.lastErr = nil
+	}()
+}
+
+func (s *remoteKeySource) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, errorInfo.WrapError(errorInfo.ErrConfigLoadFailed, "JWKS", "fetch", "invalid JWKS URL", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errorInfo.WrapError(errorInfo.ErrExternalServiceUnavailable, "JWKS", "fetch", "failed to download JWKS", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorInfo.NewAppError(errorInfo.ErrExternalServiceBadResponse, "JWKS", "fetch", fmt.Sprintf("unexpected JWKS status %d", resp.StatusCode))
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, errorInfo.WrapError(errorInfo.ErrExternalServiceBadResponse, "JWKS", "fetch", "failed to read JWKS response", err)
+	}
+	return parseKeySet(raw)
+}
+
+func lookupKey(keys map[string]crypto.PublicKey, kid string) (crypto.PublicKey, error) {
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	// Un JWKS con una sola clave sin kid se usa para tokens que no declaran kid.
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+	return nil, errorInfo.NewAppError(errorInfo.ErrUnauthorized, "JWKS", "lookupKey", "unknown signing key: "+kid)
+}
+
+func parseKeySet(raw []byte) (map[string]crypto.PublicKey, error) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, errorInfo.WrapError(errorInfo.ErrConfigLoadFailed, "JWKS", "parseKeySet", "invalid JWKS document", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, errorInfo.WrapError(errorInfo.ErrConfigLoadFailed, "JWKS", "parseKeySet", "invalid key "+jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errorInfo.NewAppError(errorInfo.ErrConfigLoadFailed, "JWKS", "parseKeySet", "JWKS contains no signing keys")
+	}
+	return keys, nil
+}
+
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
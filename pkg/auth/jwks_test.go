@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// jwksServer sirve un JWKS con una clave "k1" mientras failing sea false.
+func jwksServer(t *testing.T, failing *atomic.Bool, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	body, _ := json.Marshal(jsonWebKeySet{Keys: []jsonWebKey{{
+		Kty: "EC", Kid: "k1", Use: "sig", Crv: "P-256",
+		X: encode(private.X.Bytes()), Y: encode(private.Y.Bytes()),
+	}}})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRemoteKeySourceSharesOneDownload(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	server := jwksServer(t, &failing, &requests)
+	source := NewRemoteKeySource(server.URL, nil)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := source.Key(context.Background(), "k1"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("JWKS downloads = %d, want 1", got)
+	}
+}
+
+func TestRemoteKeySourceThrottlesFailedRefreshes(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	server := jwksServer(t, &failing, &requests)
+	source := NewRemoteKeySource(server.URL, nil).(*remoteKeySource)
+
+	if _, err := source.Key(context.Background(), "k1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Las claves vencen y el proveedor cae: se sigue respondiendo con la caché y
+	// se intenta descargar una sola vez por minRefresh.
+	failing.Store(true)
+	source.mu.Lock()
+	source.fetchedAt = time.Now().Add(-2 * source.ttl)
+	source.lastAttempt = source.fetchedAt
+	source.mu.Unlock()
+
+	for range 10 {
+		if _, err := source.Key(context.Background(), "k1"); err != nil {
+			t.Fatalf("cached key not served: %v", err)
+		}
+	}
+	waitRefresh(source)
+	for range 10 {
+		if _, err := source.Key(context.Background(), "k1"); err != nil {
+			t.Fatalf("cached key not served after failed refresh: %v", err)
+		}
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("JWKS downloads = %d, want 2", got)
+	}
+}
+
+func TestRemoteKeySourceReportsFailureWithoutKeys(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	failing.Store(true)
+	server := jwksServer(t, &failing, &requests)
+	source := NewRemoteKeySource(server.URL, nil)
+
+	for range 3 {
+		if _, err := source.Key(context.Background(), "k1"); err == nil {
+			t.Fatal("expected an error without keys")
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("JWKS downloads = %d, want 1", got)
+	}
+}
+
+func waitRefresh(source *remoteKeySource) {
+	source.mu.Lock()
+	done := source.refreshing
+	source.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+}
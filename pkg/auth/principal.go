@@ -0,0 +1,54 @@
+package auth
+
+import "context"
+
+const (
+	MethodJWT = "jwt"
+)
+
+const (
+	RoleDoctor   = "doctor"
+	RolePatient  = "patient"
+	RoleEmployer = "employer"
+	RoleInsurer  = "insurer"
+	RoleAdmin    = "admin"
+)
+
+type Principal struct {
+	Subject  string
+	Roles    []string
+	DoctorID string
+	Rut      string
+	Workers  []string
+	Method   string
+	APIKey   string
+}
+
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Principal) HasWorker(rut string) bool {
+	for _, worker := range p.Workers {
+		if worker == rut {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	errorInfo "license-service/pkg/log/error"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type TokenValidatorConfig struct {
+	Issuer    string
+	Audience  string
+	ClockSkew time.Duration
+}
+
+type TokenValidator struct {
+	keys       KeySource
+	parser     *jwt.Parser
+	configured bool
+}
+
+type licenseClaims struct {
+	jwt.RegisteredClaims
+	Roles    []string `json:"roles"`
+	DoctorID string   `json:"doctor_id"`
+	Rut      string   `json:"rut"`
+	Workers  []string `json:"workers"`
+}
+
+// NewTokenValidator siempre exige iss y aud. jwt omite la comprobación cuando
+// el valor esperado está vacío, así que en ese caso Validate rechaza todo token.
+func NewTokenValidator(keys KeySource, config TokenValidatorConfig) *TokenValidator {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithLeeway(config.ClockSkew),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuer(config.Issuer),
+		jwt.WithAudience(config.Audience),
+	}
+
+	return &TokenValidator{
+		keys:       keys,
+		parser:     jwt.NewParser(options...),
+		configured: config.Issuer != "" && config.Audience != "",
+	}
+}
+
+func (v *TokenValidator) Validate(ctx context.Context, rawToken string) (*Principal, error) {
+	if !v.configured {
+		return nil, errorInfo.NewAppError(errorInfo.ErrUnauthorized, "TokenValidator", "Validate", "token issuer and audience are not configured")
+	}
+
+	var claims licenseClaims
+	_, err := v.parser.ParseWithClaims(rawToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, errorInfo.WrapError(errorInfo.ErrUnauthorized, "TokenValidator", "Validate", "invalid bearer token", err)
+	}
+
+	if claims.Subject == "" {
+		return nil, errorInfo.NewAppError(errorInfo.ErrUnauthorized, "TokenValidator", "Validate", "token has no subject")
+	}
+
+	return &Principal{
+		Subject:  claims.Subject,
+		Roles:    claims.Roles,
+		DoctorID: claims.DoctorID,
+		Rut:      claims.Rut,
+		Workers:  claims.Workers,
+		Method:   MethodJWT,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package backoff
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Exponential calcula la espera antes de cada reintento: Initial*Multiplier^n
+// acotada por Max, con jitter sobre la mitad superior para que varias réplicas
+// no reintenten al mismo tiempo.
+type Exponential struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// Delay devuelve la espera antes del intento attempt+1 (attempt empieza en 0).
+func (b Exponential) Delay(attempt int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(attempt))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+
+	half := delay / 2
+	return time.Duration(half + rand.Float64()*half)
+}
@@ -0,0 +1,18 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Backend es un almacén clave/valor con expiración. El LRU en memoria es la
+// implementación por defecto; un backend externo (Redis, Memcached) permite que
+// varias réplicas compartan entradas e invalidaciones.
+type Backend interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	// Clear descarta todas las entradas; se usa cuando pudieron perderse
+	// invalidaciones.
+	Clear(ctx context.Context) error
+}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Guard envuelve un Backend para que una lectura lenta de la fuente no vuelva a
+// cachear un valor que una escritura invalidó mientras tanto. Quien carga una
+// clave toma un Ticket con Begin antes de leer la fuente y guarda el resultado
+// con SetIfCurrent, que lo descarta si hubo un Delete o un Clear desde Begin.
+type Guard struct {
+	Backend
+
+	mu    sync.Mutex
+	epoch uint64
+	loads map[string]*pendingLoad
+}
+
+// pendingLoad existe solo mientras alguien carga la clave, así el mapa no crece
+// con cada clave invalidada.
+type pendingLoad struct {
+	readers    int
+	generation uint64
+}
+
+type Ticket struct {
+	key        string
+	generation uint64
+	epoch      uint64
+}
+
+func NewGuard(backend Backend) *Guard {
+	return &Guard{Backend: backend, loads: make(map[string]*pendingLoad)}
+}
+
+// Begin se llama antes de leer la fuente; cada Ticket se libera con Release.
+func (g *Guard) Begin(key string) Ticket {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	load, ok := g.loads[key]
+	if !ok {
+		load = &pendingLoad{}
+		g.loads[key] = load
+	}
+	load.readers++
+	return Ticket{key: key, generation: load.generation, epoch: g.epoch}
+}
+
+func (g *Guard) Release(ticket Ticket) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if load, ok := g.loads[ticket.key]; ok {
+		load.readers--
+		if load.readers == 0 {
+			delete(g.loads, ticket.key)
+		}
+	}
+}
+
+// SetIfCurrent guarda el valor solo si la clave no se invalidó desde Begin e
+// informa si lo hizo. Escribe con el lock tomado: un Delete posterior borra
+// siempre después de esta escritura.
+func (g *Guard) SetIfCurrent(ctx context.Context, ticket Ticket, value []byte, ttl time.Duration) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	load, ok := g.loads[ticket.key]
+	if !ok || load.generation != ticket.generation || g.epoch != ticket.epoch {
+		return false, nil
+	}
+	return true, g.Backend.Set(ctx, ticket.key, value, ttl)
+}
+
+func (g *Guard) Delete(ctx context.Context, key string) error {
+	g.mu.Lock()
+	if load, ok := g.loads[key]; ok {
+		load.generation++
+	}
+	g.mu.Unlock()
+	return g.Backend.Delete(ctx, key)
+}
+
+func (g *Guard) Clear(ctx context.Context) error {
+	g.mu.Lock()
+	g.epoch++
+	g.mu.Unlock()
+	return g.Backend.Clear(ctx)
+}
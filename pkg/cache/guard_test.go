@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGuardSkipsInvalidatedLoads(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		invalidate func(*Guard)
+		want       bool
+	}{
+		{"untouched", func(*Guard) {}, true},
+		{"other key deleted", func(g *Guard) { _ = g.Delete(ctx, "other") }, true},
+		{"key deleted", func(g *Guard) { _ = g.Delete(ctx, "key") }, false},
+		{"cleared", func(g *Guard) { _ = g.Clear(ctx) }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := NewGuard(NewLRU(0))
+			ticket := guard.Begin("key")
+			tt.invalidate(guard)
+
+			stored, err := guard.SetIfCurrent(ctx, ticket, []byte("v"), 0)
+			guard.Release(ticket)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored != tt.want || has(t, guard, "key") != tt.want {
+				t.Errorf("stored = %v, want %v", stored, tt.want)
+			}
+			if len(guard.loads) != 0 {
+				t.Errorf("%d loads left after Release", len(guard.loads))
+			}
+		})
+	}
+}
+
+func TestGuardTracksConcurrentLoads(t *testing.T) {
+	ctx := context.Background()
+	guard := NewGuard(NewLRU(0))
+
+	early := guard.Begin("key")
+	_ = guard.Delete(ctx, "key")
+	late := guard.Begin("key")
+
+	if stored, _ := guard.SetIfCurrent(ctx, early, []byte("old"), 0); stored {
+		t.Error("load started before the delete was stored")
+	}
+	if stored, _ := guard.SetIfCurrent(ctx, late, []byte("new"), 0); !stored {
+		t.Error("load started after the delete was skipped")
+	}
+	guard.Release(early)
+	guard.Release(late)
+	if len(guard.loads) != 0 {
+		t.Errorf("%d loads left after Release", len(guard.loads))
+	}
+}
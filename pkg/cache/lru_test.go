@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// clock es un reloj manual para vencer entradas sin esperar.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time          { return c.now }
+func (c *clock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestLRU(maxEntries int) (*LRU, *clock) {
+	c := &clock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	lru := NewLRU(maxEntries)
+	lru.now = c.Now
+	return lru, c
+}
+
+func has(t *testing.T, backend Backend, key string) bool {
+	t.Helper()
+	_, found, err := backend.Get(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return found
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	lru, _ := newTestLRU(3)
+	ctx := context.Background()
+	for _, key := range []string{"a", "b", "c"} {
+		_ = lru.Set(ctx, key, []byte(key), 0)
+	}
+
+	// Leer "a" la vuelve la más reciente: el próximo Set descarta "b".
+	has(t, lru, "a")
+	_ = lru.Set(ctx, "d", []byte("d"), 0)
+	if has(t, lru, "b") {
+		t.Fatal("b should have been evicted")
+	}
+
+	// Reescribir "c" también la renueva: ahora se descarta "a".
+	_ = lru.Set(ctx, "c", []byte("c2"), 0)
+	_ = lru.Set(ctx, "e", []byte("e"), 0)
+	if has(t, lru, "a") {
+		t.Fatal("a should have been evicted")
+	}
+	for _, key := range []string{"c", "d", "e"} {
+		if !has(t, lru, key) {
+			t.Errorf("%s evicted", key)
+		}
+	}
+	if lru.Len() != 3 {
+		t.Errorf("Len = %d, want 3", lru.Len())
+	}
+	if value, _, _ := lru.Get(ctx, "c"); string(value) != "c2" {
+		t.Errorf("c = %q, want the rewritten value", value)
+	}
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	lru, clock := newTestLRU(0)
+	ctx := context.Background()
+	_ = lru.Set(ctx, "positive", []byte("{}"), time.Hour)
+	_ = lru.Set(ctx, "negative", []byte("null"), time.Minute)
+	_ = lru.Set(ctx, "forever", []byte("{}"), 0)
+
+	clock.Advance(time.Minute)
+	if !has(t, lru, "negative") {
+		t.Fatal("negative entry expired before its TTL")
+	}
+
+	clock.Advance(time.Second)
+	if has(t, lru, "negative") {
+		t.Fatal("negative entry served after its TTL")
+	}
+	if !has(t, lru, "positive") {
+		t.Fatal("positive entry expired with the negative TTL")
+	}
+
+	clock.Advance(time.Hour)
+	if has(t, lru, "positive") {
+		t.Fatal("positive entry served after its TTL")
+	}
+	if !has(t, lru, "forever") {
+		t.Fatal("entry without TTL expired")
+	}
+	if lru.Len() != 1 {
+		t.Errorf("Len = %d, want expired entries removed", lru.Len())
+	}
+}
+
+func TestLRUSetRenewsTTL(t *testing.T) {
+	lru, clock := newTestLRU(0)
+	ctx := context.Background()
+	_ = lru.Set(ctx, "key", []byte("null"), time.Minute)
+	clock.Advance(50 * time.Second)
+	_ = lru.Set(ctx, "key", []byte("{}"), time.Hour)
+	clock.Advance(time.Minute)
+
+	if !has(t, lru, "key") {
+		t.Fatal("rewritten entry kept the old TTL")
+	}
+}
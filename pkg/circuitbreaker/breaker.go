@@ -0,0 +1,162 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// ErrOpen lo devuelve Do sin ejecutar la operación mientras el circuito está
+// abierto o ya hay suficientes sondas en curso en half-open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+type Config struct {
+	// FailureThreshold es la cantidad de fallos consecutivos que abre el circuito.
+	FailureThreshold int
+	// OpenTimeout es cuánto permanece abierto antes de dejar pasar sondas.
+	OpenTimeout time.Duration
+	// HalfOpenMaxCalls es la cantidad de sondas simultáneas en half-open.
+	HalfOpenMaxCalls int
+	// IsFailure decide qué errores cuentan como fallo; por defecto todos.
+	IsFailure func(err error) bool
+	// OnStateChange se llama con el lock liberado después de cada transición.
+	OnStateChange func(from, to State)
+}
+
+type Breaker struct {
+	config Config
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+func New(config Config) *Breaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = 30 * time.Second
+	}
+	if config.HalfOpenMaxCalls <= 0 {
+		config.HalfOpenMaxCalls = 1
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = func(err error) bool { return err != nil }
+	}
+	return &Breaker{config: config, now: time.Now}
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.currentState()
+}
+
+// Do ejecuta fn si el circuito lo permite y registra el resultado.
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.acquire(); err != nil {
+		return err
+	}
+
+	err := fn()
+	b.release(err != nil && b.config.IsFailure(err))
+	return err
+}
+
+func (b *Breaker) acquire() error {
+	b.mu.Lock()
+	from := b.state
+	state := b.currentState()
+
+	switch state {
+	case StateOpen:
+		b.mu.Unlock()
+		return ErrOpen
+	case StateHalfOpen:
+		if b.probes >= b.config.HalfOpenMaxCalls {
+			b.mu.Unlock()
+			return ErrOpen
+		}
+		b.probes++
+	}
+	b.state = state
+	b.mu.Unlock()
+
+	b.notify(from, state)
+	return nil
+}
+
+func (b *Breaker) release(failed bool) {
+	b.mu.Lock()
+	from := b.state
+
+	switch {
+	case from == StateHalfOpen && failed:
+		b.probes--
+		b.trip()
+	case from == StateHalfOpen:
+		b.probes--
+		b.reset()
+	case failed:
+		b.failures++
+		if b.failures >= b.config.FailureThreshold {
+			b.trip()
+		}
+	default:
+		b.failures = 0
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+// currentState pasa de open a half-open cuando vence OpenTimeout. Requiere el lock.
+func (b *Breaker) currentState() State {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.config.OpenTimeout {
+		b.probes = 0
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+func (b *Breaker) trip() {
+	b.state = StateOpen
+	b.openedAt = b.now()
+	b.failures = 0
+}
+
+func (b *Breaker) reset() {
+	b.state = StateClosed
+	b.failures = 0
+	b.probes = 0
+}
+
+func (b *Breaker) notify(from, to State) {
+	if from != to && b.config.OnStateChange != nil {
+		b.config.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,176 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	errInfra  = errors.New("database unavailable")
+	errClient = errors.New("duplicate key")
+)
+
+// clock es un reloj manual para recorrer OpenTimeout sin esperar.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time          { return c.now }
+func (c *clock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestBreaker(config Config) (*Breaker, *clock, *[]string) {
+	c := &clock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	transitions := &[]string{}
+	config.IsFailure = func(err error) bool { return errors.Is(err, errInfra) }
+	config.OnStateChange = func(from, to State) {
+		*transitions = append(*transitions, from.String()+"->"+to.String())
+	}
+	breaker := New(config)
+	breaker.now = c.Now
+	return breaker, c, transitions
+}
+
+func fail(err error) func() error { return func() error { return err } }
+
+func succeed() error { return nil }
+
+func TestOpensAtThreshold(t *testing.T) {
+	breaker, _, transitions := newTestBreaker(Config{FailureThreshold: 3, OpenTimeout: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		_ = breaker.Do(fail(errInfra))
+	}
+	if breaker.State() != StateClosed {
+		t.Fatalf("State = %s before the threshold, want closed", breaker.State())
+	}
+
+	if err := breaker.Do(fail(errInfra)); !errors.Is(err, errInfra) {
+		t.Fatalf("Do = %v, want the operation error", err)
+	}
+	if breaker.State() != StateOpen {
+		t.Fatalf("State = %s at the threshold, want open", breaker.State())
+	}
+
+	called := false
+	if err := breaker.Do(func() error { called = true; return nil }); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Do while open = %v, want ErrOpen", err)
+	}
+	if called {
+		t.Fatal("operation ran while the circuit was open")
+	}
+	if len(*transitions) != 1 || (*transitions)[0] != "closed->open" {
+		t.Errorf("transitions = %v", *transitions)
+	}
+}
+
+func TestSuccessResetsConsecutiveFailures(t *testing.T) {
+	breaker, _, _ := newTestBreaker(Config{FailureThreshold: 3})
+
+	_ = breaker.Do(fail(errInfra))
+	_ = breaker.Do(fail(errInfra))
+	_ = breaker.Do(succeed)
+	_ = breaker.Do(fail(errInfra))
+	_ = breaker.Do(fail(errInfra))
+
+	if breaker.State() != StateClosed {
+		t.Fatalf("State = %s, want closed: failures were not consecutive", breaker.State())
+	}
+}
+
+func TestIgnoredErrorsDoNotCount(t *testing.T) {
+	breaker, _, _ := newTestBreaker(Config{FailureThreshold: 2})
+
+	for i := 0; i < 10; i++ {
+		if err := breaker.Do(fail(errClient)); !errors.Is(err, errClient) {
+			t.Fatalf("Do = %v, want the operation error", err)
+		}
+	}
+	if breaker.State() != StateClosed {
+		t.Fatalf("State = %s, want closed", breaker.State())
+	}
+}
+
+func TestHalfOpenProbeSucceeds(t *testing.T) {
+	breaker, clock, transitions := newTestBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Minute})
+	_ = breaker.Do(fail(errInfra))
+
+	clock.Advance(59 * time.Second)
+	if breaker.State() != StateOpen {
+		t.Fatalf("State = %s before OpenTimeout, want open", breaker.State())
+	}
+	clock.Advance(time.Second)
+	if breaker.State() != StateHalfOpen {
+		t.Fatalf("State = %s after OpenTimeout, want half_open", breaker.State())
+	}
+
+	if err := breaker.Do(succeed); err != nil {
+		t.Fatalf("probe = %v", err)
+	}
+	if breaker.State() != StateClosed {
+		t.Fatalf("State = %s after a good probe, want closed", breaker.State())
+	}
+	want := []string{"closed->open", "open->half_open", "half_open->closed"}
+	if len(*transitions) != len(want) {
+		t.Fatalf("transitions = %v, want %v", *transitions, want)
+	}
+	for i := range want {
+		if (*transitions)[i] != want[i] {
+			t.Errorf("transitions = %v, want %v", *transitions, want)
+		}
+	}
+}
+
+func TestHalfOpenProbeFails(t *testing.T) {
+	breaker, clock, _ := newTestBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Minute})
+	_ = breaker.Do(fail(errInfra))
+	clock.Advance(time.Minute)
+
+	if err := breaker.Do(fail(errInfra)); !errors.Is(err, errInfra) {
+		t.Fatalf("probe = %v, want the operation error", err)
+	}
+	if breaker.State() != StateOpen {
+		t.Fatalf("State = %s after a failed probe, want open", breaker.State())
+	}
+
+	// Vuelve a esperar un OpenTimeout completo desde la sonda fallida.
+	clock.Advance(59 * time.Second)
+	if err := breaker.Do(succeed); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Do = %v, want ErrOpen", err)
+	}
+}
+
+func TestHalfOpenLimitsConcurrentProbes(t *testing.T) {
+	breaker, clock, _ := newTestBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Minute, HalfOpenMaxCalls: 2})
+	_ = breaker.Do(fail(errInfra))
+	clock.Advance(time.Minute)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = breaker.Do(func() error {
+				started <- struct{}{}
+				<-release
+				return nil
+			})
+		}()
+	}
+	<-started
+	<-started
+
+	if err := breaker.Do(succeed); !errors.Is(err, ErrOpen) {
+		t.Fatalf("third probe = %v, want ErrOpen", err)
+	}
+
+	close(release)
+	wg.Wait()
+	if breaker.State() != StateClosed {
+		t.Fatalf("State = %s after good probes, want closed", breaker.State())
+	}
+	if err := breaker.Do(succeed); err != nil {
+		t.Fatalf("Do after closing = %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package env
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envLoader aplica variables de entorno sobre la configuración ya cargada. Un
+// valor mal formado no se ignora: se acumula y Load falla con todos los errores.
+type envLoader struct {
+	explicit map[string]bool
+	errs     []string
+}
+
+func applyEnv(config *Config, explicit map[string]bool) error {
+	l := &envLoader{explicit: explicit}
+
+	l.string("POSTGRES_HOST", &config.Database.Host)
+	l.string("POSTGRES_PORT", &config.Database.Port)
+	l.string("POSTGRES_USERNAME", &config.Database.Username)
+	l.string("POSTGRES_PASSWORD", &config.Database.Password)
+	l.string("POSTGRES_NAME", &config.Database.Name)
+	l.string("POSTGRES_SSLMODE", &config.Database.SSLMode)
+	l.string("POSTGRES_TIMEZONE", &config.Database.TimeZone)
+	l.string("POSTGRES_URL", &config.Database.Url)
+	l.int("DB_MAX_IDLE_CONNS", &config.Database.MaxIdleConns)
+	l.int("DB_MAX_OPEN_CONNS", &config.Database.MaxOpenConns)
+	l.duration("DB_CONN_MAX_LIFETIME", time.Second, &config.Database.ConnMaxLifetime)
+	l.string("GORM_LOG_LEVEL", &config.Database.GormLogLevel)
+	l.duration("GORM_SLOW_THRESHOLD", time.Millisecond, &config.Database.SlowThreshold)
+	l.bool("DB_AUTO_MIGRATE", &config.Database.AutoMigrate)
+	l.int("DB_CONNECT_MAX_ATTEMPTS", &config.Database.ConnectMaxAttempts)
+	l.duration("DB_CONNECT_INITIAL_BACKOFF", time.Millisecond, &config.Database.ConnectInitialBackoff)
+	l.duration("DB_CONNECT_MAX_BACKOFF", time.Second, &config.Database.ConnectMaxBackoff)
+	l.bool("DB_LAZY_CONNECT", &config.Database.LazyConnect)
+	l.duration("DB_QUERY_TIMEOUT", time.Second, &config.Database.QueryTimeout)
+	l.bool("DB_BREAKER_ENABLED", &config.Database.CircuitBreaker.Enabled)
+	l.int("DB_BREAKER_FAILURE_THRESHOLD", &config.Database.CircuitBreaker.FailureThreshold)
+	l.duration("DB_BREAKER_OPEN_TIMEOUT", time.Second, &config.Database.CircuitBreaker.OpenTimeout)
+	l.int("DB_BREAKER_HALF_OPEN_MAX_CALLS", &config.Database.CircuitBreaker.HalfOpenMaxCalls)
+
+	l.string("PORT", &config.Server.Port)
+	l.string("HOST", &config.Server.Host)
+	l.duration("SERVER_READ_TIMEOUT", time.Second, &config.Server.ReadTimeout)
+	l.duration("SERVER_READ_HEADER_TIMEOUT", time.Second, &config.Server.ReadHeaderTimeout)
+	l.duration("SERVER_WRITE_TIMEOUT", time.Second, &config.Server.WriteTimeout)
+	l.duration("SERVER_IDLE_TIMEOUT", time.Second, &config.Server.IdleTimeout)
+	l.int("SERVER_MAX_HEADER_BYTES", &config.Server.MaxHeaderBytes)
+	l.duration("SERVER_SHUTDOWN_TIMEOUT", time.Second, &config.Server.ShutdownTimeout)
+
+	l.string("APP_ENV", &config.App.Environment)
+	l.string("LOG_LEVEL", &config.App.LogLevel)
+	l.stringAs("LOG_FORMAT", "app.log_format", &config.App.LogFormat)
+	l.bool("LOG_MASK_PII", &config.App.LogMaskPII)
+
+	l.boolAs("AUTH_ENABLED", "auth.enabled", &config.Auth.Enabled)
+	l.string("AUTH_JWKS_FILE", &config.Auth.JWKSFile)
+	l.string("AUTH_JWKS_URL", &config.Auth.JWKSURL)
+	l.string("AUTH_ISSUER", &config.Auth.Issuer)
+	l.string("AUTH_AUDIENCE", &config.Auth.Audience)
+	l.duration("AUTH_CLOCK_SKEW", time.Second, &config.Auth.ClockSkew)
+
+	l.bool("RATE_LIMIT_ENABLED", &config.RateLimit.Enabled)
+	l.int("RATE_LIMIT_DEFAULT_RPM", &config.RateLimit.Default.RequestsPerMinute)
+	l.int("RATE_LIMIT_DEFAULT_BURST", &config.RateLimit.Default.Burst)
+	l.int("RATE_LIMIT_VERIFY_RPM", &config.RateLimit.Verify.RequestsPerMinute)
+	l.int("RATE_LIMIT_VERIFY_BURST", &config.RateLimit.Verify.Burst)
+	l.int("RATE_LIMIT_VERIFY_BATCH_RPM", &config.RateLimit.VerifyBatch.RequestsPerMinute)
+	l.int("RATE_LIMIT_VERIFY_BATCH_BURST", &config.RateLimit.VerifyBatch.Burst)
+	l.int("RATE_LIMIT_ISSUE_RPM", &config.RateLimit.Issue.RequestsPerMinute)
+	l.int("RATE_LIMIT_ISSUE_BURST", &config.RateLimit.Issue.Burst)
+
+	l.bool("CACHE_ENABLED", &config.Cache.Enabled)
+	l.string("CACHE_BACKEND", &config.Cache.Backend)
+	l.int("CACHE_MAX_ENTRIES", &config.Cache.MaxEntries)
+	l.duration("CACHE_TTL", time.Second, &config.Cache.TTL)
+	l.duration("CACHE_NEGATIVE_TTL", time.Second, &config.Cache.NegativeTTL)
+
+	l.int("VERIFY_BATCH_MAX_SIZE", &config.Verification.BatchMaxSize)
+	l.int("IMPORT_CHUNK_SIZE", &config.Import.ChunkSize)
+	l.int("IMPORT_MAX_BYTES", &config.Import.MaxBytes)
+
+	l.int("EXPORT_PAGE_SIZE", &config.Export.PageSize)
+	l.string("EXPORT_DIR", &config.Export.Dir)
+	l.duration("EXPORT_JOB_TTL", time.Second, &config.Export.JobTTL)
+	l.int("EXPORT_MAX_RUNNING_JOBS", &config.Export.MaxRunningJobs)
+	l.string("EXPORT_PSEUDONYM_KEY", &config.Export.PseudonymKey)
+
+	l.bool("TRACING_ENABLED", &config.Tracing.Enabled)
+	l.string("TRACING_EXPORTER", &config.Tracing.Exporter)
+	l.string("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", &config.Tracing.OTLPEndpoint)
+	l.string("TRACING_FILE", &config.Tracing.FilePath)
+	l.string("OTEL_SERVICE_NAME", &config.Tracing.ServiceName)
+	l.float("TRACING_SAMPLE_RATIO", &config.Tracing.SampleRatio)
+
+	if len(l.errs) > 0 {
+		return configError("applyEnv", "invalid environment variables: "+strings.Join(l.errs, "; "), nil)
+	}
+	return nil
+}
+
+// lookupEnv devuelve KEY o, si no está definida, el contenido del archivo
+// indicado en KEY_FILE (secretos montados por Docker/Kubernetes).
+func lookupEnv(key string) string {
+	value, _ := lookupEnvWithError(key)
+	return value
+}
+
+func lookupEnvWithError(key string) (string, error) {
+	if value := os.Getenv(key); value != "" {
+		return value, nil
+	}
+
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return "", nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(raw), "\r\n"), nil
+}
+
+func (l *envLoader) lookup(key string) (string, bool) {
+	value, err := lookupEnvWithError(key)
+	if err != nil {
+		l.errs = append(l.errs, key+"_FILE: "+err.Error())
+		return "", false
+	}
+	return value, value != ""
+}
+
+func (l *envLoader) string(key string, target *string) {
+	l.stringAs(key, "", target)
+}
+
+func (l *envLoader) stringAs(key, name string, target *string) {
+	if value, ok := l.lookup(key); ok {
+		*target = value
+		l.mark(name)
+	}
+}
+
+func (l *envLoader) int(key string, target *int) {
+	if value, ok := l.lookup(key); ok {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			l.errs = append(l.errs, key+" must be an integer")
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (l *envLoader) bool(key string, target *bool) {
+	l.boolAs(key, "", target)
+}
+
+func (l *envLoader) boolAs(key, name string, target *bool) {
+	if value, ok := l.lookup(key); ok {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			l.errs = append(l.errs, key+" must be a boolean")
+			return
+		}
+		*target = parsed
+		l.mark(name)
+	}
+}
+
+func (l *envLoader) float(key string, target *float64) {
+	if value, ok := l.lookup(key); ok {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			l.errs = append(l.errs, key+" must be a number")
+			return
+		}
+		*target = parsed
+	}
+}
+
+// duration acepta un entero en la unidad histórica de la variable (segundos o
+// milisegundos) o una duración de Go como "1m30s".
+func (l *envLoader) duration(key string, unit time.Duration, target *time.Duration) {
+	if value, ok := l.lookup(key); ok {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			*target = time.Duration(parsed) * unit
+			return
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			l.errs = append(l.errs, key+" must be an integer or a duration")
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (l *envLoader) mark(name string) {
+	if name != "" {
+		l.explicit[name] = true
+	}
+}
@@ -0,0 +1,30 @@
+package env
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadFile superpone un archivo YAML o JSON (JSON es YAML válido) sobre config.
+// Las duraciones se escriben como "30s" o "1h".
+func loadFile(path string, config *Config, explicit map[string]bool) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return configError("loadFile", "failed to read config file "+path, err)
+	}
+
+	if err := yaml.Unmarshal(raw, config); err != nil {
+		return configError("loadFile", "failed to parse config file "+path, err)
+	}
+
+	var present map[string]map[string]interface{}
+	if err := yaml.Unmarshal(raw, &present); err == nil {
+		for section, fields := range present {
+			for field := range fields {
+				explicit[section+"."+field] = true
+			}
+		}
+	}
+	return nil
+}
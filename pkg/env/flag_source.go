@@ -0,0 +1,66 @@
+package env
+
+import (
+	"flag"
+	"io"
+)
+
+type flagValues struct {
+	set            *flag.FlagSet
+	configFile     string
+	environment    string
+	port           string
+	host           string
+	logLevel       string
+	logFormat      string
+	databaseURL    string
+	authEnabled    bool
+	tracingEnabled bool
+}
+
+func parseFlags(args []string) (*flagValues, error) {
+	values := &flagValues{set: flag.NewFlagSet("license-service", flag.ContinueOnError)}
+	fs := values.set
+	fs.SetOutput(io.Discard)
+
+	fs.StringVar(&values.configFile, "config", "", "path to a YAML or JSON config file")
+	fs.StringVar(&values.environment, "env", "", "application environment (APP_ENV)")
+	fs.StringVar(&values.port, "port", "", "HTTP port (PORT)")
+	fs.StringVar(&values.host, "host", "", "HTTP bind host (HOST)")
+	fs.StringVar(&values.logLevel, "log-level", "", "log level (LOG_LEVEL)")
+	fs.StringVar(&values.logFormat, "log-format", "", "log format: json or text (LOG_FORMAT)")
+	fs.StringVar(&values.databaseURL, "database-url", "", "PostgreSQL URL (POSTGRES_URL)")
+	fs.BoolVar(&values.authEnabled, "auth-enabled", false, "require authentication (AUTH_ENABLED)")
+	fs.BoolVar(&values.tracingEnabled, "tracing-enabled", false, "export traces (TRACING_ENABLED)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, configError("parseFlags", "invalid command line flags", err)
+	}
+	return values, nil
+}
+
+// apply solo sobrescribe los flags presentes en la línea de comandos.
+func (f *flagValues) apply(config *Config, explicit map[string]bool) {
+	f.set.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "env":
+			config.App.Environment = f.environment
+		case "port":
+			config.Server.Port = f.port
+		case "host":
+			config.Server.Host = f.host
+		case "log-level":
+			config.App.LogLevel = f.logLevel
+		case "log-format":
+			config.App.LogFormat = f.logFormat
+			explicit["app.log_format"] = true
+		case "database-url":
+			config.Database.Url = f.databaseURL
+		case "auth-enabled":
+			config.Auth.Enabled = f.authEnabled
+			explicit["auth.enabled"] = true
+		case "tracing-enabled":
+			config.Tracing.Enabled = f.tracingEnabled
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package env
+
+import (
+	"strconv"
+	"strings"
+
+	logger "license-service/pkg/log/logger"
+)
+
+// Validate revisa la configuración completa y devuelve todos los problemas juntos.
+// En producción además rechaza secretos que siguen con su valor por defecto.
+func Validate(config *Config) error {
+	var problems []string
+	check := func(ok bool, message string) {
+		if !ok {
+			problems = append(problems, message)
+		}
+	}
+
+	check(isPort(config.Server.Port), "server.port must be a valid TCP port")
+	check(config.Database.Url != "" || isPort(config.Database.Port), "database.port must be a valid TCP port")
+	check(config.Database.MaxOpenConns > 0, "database.max_open_conns must be greater than 0")
+	check(config.Database.MaxIdleConns >= 0, "database.max_idle_conns cannot be negative")
+	check(config.Database.ConnectMaxAttempts > 0, "database.connect_max_attempts must be greater than 0")
+	check(config.Database.ConnectInitialBackoff > 0 && config.Database.ConnectMaxBackoff >= config.Database.ConnectInitialBackoff,
+		"database.connect_initial_backoff must be positive and not greater than database.connect_max_backoff")
+	check(config.Database.QueryTimeout > 0, "database.query_timeout must be greater than 0")
+	if config.Database.CircuitBreaker.Enabled {
+		check(config.Database.CircuitBreaker.FailureThreshold > 0 && config.Database.CircuitBreaker.OpenTimeout > 0 && config.Database.CircuitBreaker.HalfOpenMaxCalls > 0,
+			"database.circuit_breaker requires positive failure_threshold, open_timeout and half_open_max_calls")
+	}
+	check(config.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be greater than 0")
+
+	check(config.App.LogFormat == logger.FormatJSON || config.App.LogFormat == logger.FormatText, "app.log_format must be json or text")
+
+	if config.Auth.Enabled {
+		check(config.Auth.JWKSFile != "" || config.Auth.JWKSURL != "", "auth.jwks_file or auth.jwks_url is required when auth is enabled")
+		// Sin iss y aud se aceptaría cualquier token firmado por una clave del
+		// JWKS, incluidos los emitidos para otros servicios.
+		check(config.Auth.Issuer != "", "auth.issuer is required when auth is enabled")
+		check(config.Auth.Audience != "", "auth.audience is required when auth is enabled")
+	}
+
+	if config.RateLimit.Enabled {
+		for name, rule := range map[string]RateLimitRule{
+			"default":      config.RateLimit.Default,
+			"verify":       config.RateLimit.Verify,
+			"verify_batch": config.RateLimit.VerifyBatch,
+			"issue":        config.RateLimit.Issue,
+		} {
+			check(rule.RequestsPerMinute > 0 && rule.Burst > 0, "rate_limit."+name+" requires positive requests_per_minute and burst")
+		}
+	}
+
+	if config.Cache.Enabled {
+		check(config.Cache.Backend == CacheBackendMemory, "cache.backend must be memory")
+		check(config.Cache.MaxEntries > 0, "cache.max_entries must be greater than 0")
+		check(config.Cache.TTL > 0, "cache.ttl must be greater than 0")
+		check(config.Cache.NegativeTTL >= 0, "cache.negative_ttl cannot be negative")
+	}
+
+	check(config.Verification.BatchMaxSize > 0, "verification.batch_max_size must be greater than 0")
+	check(config.Import.ChunkSize > 0, "import.chunk_size must be greater than 0")
+	check(config.Import.MaxBytes > 0, "import.max_bytes must be greater than 0")
+	check(config.Export.PageSize > 0, "export.page_size must be greater than 0")
+	check(config.Export.Dir != "", "export.dir is required")
+	check(config.Export.JobTTL > 0, "export.job_ttl must be greater than 0")
+	check(config.Export.MaxRunningJobs > 0, "export.max_running_jobs must be greater than 0")
+
+	if config.Tracing.Enabled {
+		switch config.Tracing.Exporter {
+		case "otlp", "stdout", "file":
+		default:
+			problems = append(problems, "tracing.exporter must be otlp, stdout or file")
+		}
+		check(config.Tracing.SampleRatio >= 0 && config.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+	}
+
+	if config.App.Environment == EnvironmentProduction {
+		check(config.Database.Url != "" || config.Database.Password != defaultDatabasePassword,
+			"database.password uses the default value; set POSTGRES_PASSWORD or POSTGRES_PASSWORD_FILE")
+	}
+
+	if len(problems) > 0 {
+		return configError("Validate", "invalid configuration: "+strings.Join(problems, "; "), nil)
+	}
+	return nil
+}
+
+func isPort(value string) bool {
+	port, err := strconv.Atoi(value)
+	return err == nil && port > 0 && port <= 65535
+}
@@ -2,96 +2,296 @@ package env // Cambiar de "configs" a "env"
 
 import (
 	"os"
-	"strconv"
+	"path/filepath"
 	"time"
 
+	errorInfo "license-service/pkg/log/error"
 	logger "license-service/pkg/log/logger"
 
 	"github.com/joho/godotenv"
 )
 
+const (
+	EnvironmentDevelopment = "development"
+	EnvironmentProduction  = "production"
+
+	CacheBackendMemory = "memory"
+
+	defaultDatabasePassword = "password"
+)
+
 type Config struct {
-	Database DatabaseConfig `json:"database"`
-	Server   ServerConfig   `json:"server"`
-	App      AppConfig      `json:"app"`
+	Database  DatabaseConfig  `json:"database" yaml:"database"`
+	Server    ServerConfig    `json:"server" yaml:"server"`
+	App       AppConfig       `json:"app" yaml:"app"`
+	Auth      AuthConfig      `json:"auth" yaml:"auth"`
+	RateLimit RateLimitConfig `json:"rate_limit" yaml:"rate_limit"`
+	Tracing   TracingConfig   `json:"tracing" yaml:"tracing"`
+	Cache     CacheConfig     `json:"cache" yaml:"cache"`
+
+	Verification VerificationConfig `json:"verification" yaml:"verification"`
+	Import       ImportConfig       `json:"import" yaml:"import"`
+	Export       ExportConfig       `json:"export" yaml:"export"`
 }
 
 type DatabaseConfig struct {
-	Host            string        `json:"host"`
-	Port            string        `json:"port"`
-	Username        string        `json:"username"`
-	Password        string        `json:"password"`
-	Name            string        `json:"name"`
-	SSLMode         string        `json:"ssl_mode"`
-	TimeZone        string        `json:"timezone"`
-	Url             string        `json:"url"`
-	MaxIdleConns    int           `json:"max_idle_conns"`
-	MaxOpenConns    int           `json:"max_open_conns"`
-	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
-	GormLogLevel    string        `json:"gorm_log_level"`
-	SlowThreshold   time.Duration `json:"slow_threshold"`
+	Host            string        `json:"host" yaml:"host"`
+	Port            string        `json:"port" yaml:"port"`
+	Username        string        `json:"username" yaml:"username"`
+	Password        string        `json:"password" yaml:"password"`
+	Name            string        `json:"name" yaml:"name"`
+	SSLMode         string        `json:"ssl_mode" yaml:"ssl_mode"`
+	TimeZone        string        `json:"timezone" yaml:"timezone"`
+	Url             string        `json:"url" yaml:"url"`
+	MaxIdleConns    int           `json:"max_idle_conns" yaml:"max_idle_conns"`
+	MaxOpenConns    int           `json:"max_open_conns" yaml:"max_open_conns"`
+	ConnMaxLifetime time.Duration `json:"conn_max_lifetime" yaml:"conn_max_lifetime"`
+	GormLogLevel    string        `json:"gorm_log_level" yaml:"gorm_log_level"`
+	SlowThreshold   time.Duration `json:"slow_threshold" yaml:"slow_threshold"`
+	AutoMigrate     bool          `json:"auto_migrate" yaml:"auto_migrate"`
+
+	ConnectMaxAttempts    int                  `json:"connect_max_attempts" yaml:"connect_max_attempts"`
+	ConnectInitialBackoff time.Duration        `json:"connect_initial_backoff" yaml:"connect_initial_backoff"`
+	ConnectMaxBackoff     time.Duration        `json:"connect_max_backoff" yaml:"connect_max_backoff"`
+	LazyConnect           bool                 `json:"lazy_connect" yaml:"lazy_connect"`
+	QueryTimeout          time.Duration        `json:"query_timeout" yaml:"query_timeout"`
+	CircuitBreaker        CircuitBreakerConfig `json:"circuit_breaker" yaml:"circuit_breaker"`
+}
+
+type CircuitBreakerConfig struct {
+	Enabled          bool          `json:"enabled" yaml:"enabled"`
+	FailureThreshold int           `json:"failure_threshold" yaml:"failure_threshold"`
+	OpenTimeout      time.Duration `json:"open_timeout" yaml:"open_timeout"`
+	HalfOpenMaxCalls int           `json:"half_open_max_calls" yaml:"half_open_max_calls"`
+}
+
+type CacheConfig struct {
+	Enabled     bool          `json:"enabled" yaml:"enabled"`
+	Backend     string        `json:"backend" yaml:"backend"`
+	MaxEntries  int           `json:"max_entries" yaml:"max_entries"`
+	TTL         time.Duration `json:"ttl" yaml:"ttl"`
+	NegativeTTL time.Duration `json:"negative_ttl" yaml:"negative_ttl"`
+}
+
+type VerificationConfig struct {
+	// BatchMaxSize es la cantidad máxima de folios por POST /licenses/verify:batch.
+	BatchMaxSize int `json:"batch_max_size" yaml:"batch_max_size"`
+}
+
+type ImportConfig struct {
+	// ChunkSize es la cantidad de filas que se confirman en cada transacción
+	// de una importación masiva.
+	ChunkSize int `json:"chunk_size" yaml:"chunk_size"`
+	// MaxBytes acota el archivo que acepta POST /licenses/import.
+	MaxBytes int `json:"max_bytes" yaml:"max_bytes"`
+}
+
+type ExportConfig struct {
+	// PageSize es la cantidad de licencias que se leen por consulta al exportar.
+	PageSize int `json:"page_size" yaml:"page_size"`
+	// Dir guarda los archivos de las exportaciones asíncronas hasta que vencen
+	// a los JobTTL de terminadas.
+	Dir            string        `json:"dir" yaml:"dir"`
+	JobTTL         time.Duration `json:"job_ttl" yaml:"job_ttl"`
+	MaxRunningJobs int           `json:"max_running_jobs" yaml:"max_running_jobs"`
+	// PseudonymKey es la clave HMAC que seudonimiza el diagnóstico. Sin ella no
+	// se puede pedir una exportación seudonimizada.
+	PseudonymKey string `json:"pseudonym_key" yaml:"pseudonym_key"`
 }
 
 type ServerConfig struct {
-	Port string `json:"port"`
-	Host string `json:"host"`
+	Port              string        `json:"port" yaml:"port"`
+	Host              string        `json:"host" yaml:"host"`
+	ReadTimeout       time.Duration `json:"read_timeout" yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `json:"read_header_timeout" yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `json:"write_timeout" yaml:"write_timeout"`
+	IdleTimeout       time.Duration `json:"idle_timeout" yaml:"idle_timeout"`
+	MaxHeaderBytes    int           `json:"max_header_bytes" yaml:"max_header_bytes"`
+	ShutdownTimeout   time.Duration `json:"shutdown_timeout" yaml:"shutdown_timeout"`
+}
+
+type AuthConfig struct {
+	Enabled   bool          `json:"enabled" yaml:"enabled"`
+	JWKSFile  string        `json:"jwks_file" yaml:"jwks_file"`
+	JWKSURL   string        `json:"jwks_url" yaml:"jwks_url"`
+	Issuer    string        `json:"issuer" yaml:"issuer"`
+	Audience  string        `json:"audience" yaml:"audience"`
+	ClockSkew time.Duration `json:"clock_skew" yaml:"clock_skew"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool          `json:"enabled" yaml:"enabled"`
+	Default RateLimitRule `json:"default" yaml:"default"`
+	Verify  RateLimitRule `json:"verify" yaml:"verify"`
+	// VerifyBatch es aparte porque cada pedido verifica hasta
+	// Verification.BatchMaxSize folios.
+	VerifyBatch RateLimitRule `json:"verify_batch" yaml:"verify_batch"`
+	Issue       RateLimitRule `json:"issue" yaml:"issue"`
+}
+
+type RateLimitRule struct {
+	RequestsPerMinute int `json:"requests_per_minute" yaml:"requests_per_minute"`
+	Burst             int `json:"burst" yaml:"burst"`
+}
+
+type TracingConfig struct {
+	Enabled      bool    `json:"enabled" yaml:"enabled"`
+	Exporter     string  `json:"exporter" yaml:"exporter"`
+	OTLPEndpoint string  `json:"otlp_endpoint" yaml:"otlp_endpoint"`
+	FilePath     string  `json:"file_path" yaml:"file_path"`
+	ServiceName  string  `json:"service_name" yaml:"service_name"`
+	Environment  string  `json:"environment" yaml:"environment"`
+	SampleRatio  float64 `json:"sample_ratio" yaml:"sample_ratio"`
 }
 
 type AppConfig struct {
-	Environment string `json:"environment"`
-	LogLevel    string `json:"log_level"`
+	Environment string `json:"environment" yaml:"environment"`
+	LogLevel    string `json:"log_level" yaml:"log_level"`
+	LogFormat   string `json:"log_format" yaml:"log_format"`
+	LogMaskPII  bool   `json:"log_mask_pii" yaml:"log_mask_pii"`
 }
 
-func Load() *Config {
+// Load arma la configuración por capas, de menor a mayor precedencia: valores por
+// defecto, archivo YAML/JSON (--config o CONFIG_FILE), variables de entorno
+// (incluido .env y la indirección KEY_FILE para secretos) y flags de línea de
+// comandos. Debe llamarse una sola vez al arrancar y la configuración resultante
+// se inyecta donde se necesite.
+func Load(args []string) (*Config, error) {
 	if err := godotenv.Load(); err != nil {
 		log := logger.NewLogger()
 		log.Info("Environment Config", "Load", "No .env file found, using environment variables")
 	}
 
-	maxIdleConns := getEnvAsInt("DB_MAX_IDLE_CONNS", 10)
-	maxOpenConns := getEnvAsInt("DB_MAX_OPEN_CONNS", 100)
-	connMaxLifetime := time.Duration(getEnvAsInt("DB_CONN_MAX_LIFETIME", 3600)) * time.Second
-	slowThreshold := time.Duration(getEnvAsInt("GORM_SLOW_THRESHOLD", 200)) * time.Millisecond
+	config := Defaults()
+	explicit := map[string]bool{}
 
+	flags, err := parseFlags(args)
+	if err != nil {
+		return nil, err
+	}
+
+	configFile := flags.configFile
+	if configFile == "" {
+		configFile = lookupEnv("CONFIG_FILE")
+	}
+	if configFile != "" {
+		if err := loadFile(configFile, config, explicit); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(config, explicit); err != nil {
+		return nil, err
+	}
+
+	flags.apply(config, explicit)
+	applyEnvironmentDefaults(config, explicit)
+
+	if err := Validate(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func Defaults() *Config {
 	return &Config{
 		Database: DatabaseConfig{
-			Host:            getEnv("POSTGRES_HOST", "localhost"),
-			Port:            getEnv("POSTGRES_PORT", "5432"),
-			Username:        getEnv("POSTGRES_USERNAME", "user"),
-			Password:        getEnv("POSTGRES_PASSWORD", "password"),
-			Name:            getEnv("POSTGRES_NAME", "health"),
-			SSLMode:         getEnv("POSTGRES_SSLMODE", "disable"),
-			TimeZone:        getEnv("POSTGRES_TIMEZONE", "America/Santiago"),
-			Url:             getEnv("POSTGRES_URL", ""),
-			MaxIdleConns:    maxIdleConns,
-			MaxOpenConns:    maxOpenConns,
-			ConnMaxLifetime: connMaxLifetime,
-			GormLogLevel:    getEnv("GORM_LOG_LEVEL", "info"),
-			SlowThreshold:   slowThreshold,
+			Host:            "localhost",
+			Port:            "5432",
+			Username:        "user",
+			Password:        defaultDatabasePassword,
+			Name:            "health",
+			SSLMode:         "disable",
+			TimeZone:        "America/Santiago",
+			MaxIdleConns:    10,
+			MaxOpenConns:    100,
+			ConnMaxLifetime: time.Hour,
+			GormLogLevel:    "info",
+			SlowThreshold:   200 * time.Millisecond,
+			AutoMigrate:     true,
+
+			ConnectMaxAttempts:    5,
+			ConnectInitialBackoff: 500 * time.Millisecond,
+			ConnectMaxBackoff:     30 * time.Second,
+			QueryTimeout:          5 * time.Second,
+			CircuitBreaker: CircuitBreakerConfig{
+				Enabled:          true,
+				FailureThreshold: 5,
+				OpenTimeout:      30 * time.Second,
+				HalfOpenMaxCalls: 1,
+			},
 		},
 		Server: ServerConfig{
-			Port: getEnv("PORT", "8081"),
-			Host: getEnv("HOST", "localhost"),
+			Port:              "8081",
+			Host:              "0.0.0.0",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   30 * time.Second,
 		},
 		App: AppConfig{
-			Environment: getEnv("APP_ENV", "development"),
-			LogLevel:    getEnv("LOG_LEVEL", "info"),
+			Environment: EnvironmentDevelopment,
+			LogLevel:    "info",
+			LogFormat:   logger.FormatText,
+			LogMaskPII:  true,
+		},
+		Auth: AuthConfig{
+			ClockSkew: 30 * time.Second,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:     true,
+			Default:     RateLimitRule{RequestsPerMinute: 120, Burst: 30},
+			Verify:      RateLimitRule{RequestsPerMinute: 60, Burst: 10},
+			VerifyBatch: RateLimitRule{RequestsPerMinute: 6, Burst: 2},
+			Issue:       RateLimitRule{RequestsPerMinute: 30, Burst: 10},
+		},
+		Cache: CacheConfig{
+			Backend:     CacheBackendMemory,
+			MaxEntries:  10000,
+			TTL:         time.Minute,
+			NegativeTTL: 10 * time.Second,
+		},
+		Verification: VerificationConfig{
+			BatchMaxSize: 500,
+		},
+		Import: ImportConfig{
+			ChunkSize: 100,
+			MaxBytes:  256 << 20,
+		},
+		Export: ExportConfig{
+			PageSize:       500,
+			Dir:            filepath.Join(os.TempDir(), "license-exports"),
+			JobTTL:         24 * time.Hour,
+			MaxRunningJobs: 2,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "otlp",
+			FilePath:    "traces.jsonl",
+			ServiceName: "license-service",
+			SampleRatio: 1.0,
 		},
 	}
 }
 
-func getEnv(key string, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// applyEnvironmentDefaults completa los valores cuyo default depende de APP_ENV
+// cuando ninguna capa los fijó explícitamente.
+func applyEnvironmentDefaults(config *Config, explicit map[string]bool) {
+	development := config.App.Environment == EnvironmentDevelopment
+
+	if !explicit["auth.enabled"] {
+		config.Auth.Enabled = !development
 	}
-	return defaultValue
+	if !explicit["app.log_format"] && !development {
+		config.App.LogFormat = logger.FormatJSON
+	}
+	config.Tracing.Environment = config.App.Environment
 }
 
-func getEnvAsInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
-		}
+func configError(operation, message string, cause error) error {
+	if cause != nil {
+		return errorInfo.WrapError(errorInfo.ErrConfigLoadFailed, "Environment Config", operation, message, cause)
 	}
-	return defaultValue
+	return errorInfo.NewAppError(errorInfo.ErrConfigLoadFailed, "Environment Config", operation, message)
 }
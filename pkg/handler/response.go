@@ -50,6 +50,22 @@ func HandleUseCaseError(w http.ResponseWriter, err error) {
 			WriteDetailedErrorResponse(w, http.StatusBadRequest, "INVALID_DATA", appErr.Message)
 		case errors.ErrNotFound:
 			WriteErrorResponse(w, http.StatusNotFound, "NOT_FOUND")
+		case errors.ErrUnauthorized:
+			WriteDetailedErrorResponse(w, http.StatusUnauthorized, "UNAUTHORIZED", appErr.Message)
+		case errors.ErrForbidden:
+			WriteDetailedErrorResponse(w, http.StatusForbidden, "FORBIDDEN", appErr.Message)
+		case errors.ErrConflict, errors.ErrAlreadyExists:
+			WriteDetailedErrorResponse(w, http.StatusConflict, "CONFLICT", appErr.Message)
+		case errors.ErrPreconditionFailed:
+			WriteDetailedErrorResponse(w, http.StatusPreconditionFailed, "PRECONDITION_FAILED", appErr.Message)
+		case errors.ErrOptimisticLockFailed:
+			WriteDetailedErrorResponse(w, http.StatusConflict, "OPTIMISTIC_LOCK_FAILED", appErr.Message)
+		case errors.ErrCircuitBreakerOpen:
+			WriteDetailedErrorResponse(w, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", appErr.Message)
+		case errors.ErrDBConnection:
+			WriteDetailedErrorResponse(w, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "The database is not available")
+		case errors.ErrDBTimeout:
+			WriteDetailedErrorResponse(w, http.StatusGatewayTimeout, "DB_TIMEOUT", "The database did not answer in time")
 		default:
 			WriteDetailedErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR", "An unexpected error occurred")
 		}
@@ -0,0 +1,34 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportHidesCheckErrors(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	registry.Register(NewChecker("database", func(context.Context) error {
+		return errors.New(`failed to connect to host=db user=licenses database=licenses`)
+	}))
+	registry.Register(NewChecker("cache", func(context.Context) error { return nil }))
+
+	report := registry.Run(context.Background())
+	if report.Status != StatusDown {
+		t.Fatalf("Status = %q, want %q", report.Status, StatusDown)
+	}
+	if report.Checks[0].Error == nil || report.Checks[1].Error != nil {
+		t.Fatalf("check errors = %v, %v", report.Checks[0].Error, report.Checks[1].Error)
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "host=db") || strings.Contains(string(body), `"error"`) {
+		t.Errorf("report leaks the check error: %s", body)
+	}
+}
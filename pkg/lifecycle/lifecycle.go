@@ -0,0 +1,76 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Hook es el par arranque/parada de un subsistema. Cualquiera de las dos
+// funciones puede ser nil.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle arranca los hooks en el orden en que se registraron y los detiene en
+// orden inverso, de modo que cada subsistema se apaga antes que sus dependencias.
+type Lifecycle struct {
+	mu      sync.Mutex
+	hooks   []Hook
+	started int
+}
+
+func New() *Lifecycle {
+	return &Lifecycle{}
+}
+
+func (l *Lifecycle) Append(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook)
+}
+
+// Start ejecuta los OnStart pendientes. Si uno falla, detiene los que ya
+// arrancaron y devuelve el error.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	l.mu.Lock()
+	hooks := l.hooks
+	l.mu.Unlock()
+
+	for i := l.started; i < len(hooks); i++ {
+		if hooks[i].OnStart != nil {
+			if err := hooks[i].OnStart(ctx); err != nil {
+				startErr := fmt.Errorf("start %s: %w", hooks[i].Name, err)
+				if stopErr := l.Stop(ctx); stopErr != nil {
+					return errors.Join(startErr, stopErr)
+				}
+				return startErr
+			}
+		}
+		l.started = i + 1
+	}
+	return nil
+}
+
+// Stop ejecuta los OnStop de los hooks arrancados en orden inverso. Sigue aunque
+// alguno falle y devuelve todos los errores juntos.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	hooks := l.hooks[:l.started]
+	l.started = 0
+	l.mu.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if hooks[i].OnStop == nil {
+			continue
+		}
+		if err := hooks[i].OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s: %w", hooks[i].Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
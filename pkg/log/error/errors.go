@@ -52,6 +52,9 @@ const (
 
 	ErrTooManyRequests ErrorCode = "TOO_MANY_REQUESTS"
 
+	ErrUnauthorized ErrorCode = "UNAUTHORIZED"
+	ErrForbidden    ErrorCode = "FORBIDDEN"
+
 	ErrOptimisticLockFailed ErrorCode = "OPTIMISTIC_LOCK_FAILED"
 
 	ErrNetworkError ErrorCode = "NETWORK_ERROR"
@@ -166,6 +169,9 @@ var ErrorCodeMap = map[ErrorCode]struct {
 
 	ErrTooManyRequests: {429, "Demasiadas solicitudes"},
 
+	ErrUnauthorized: {401, "No autenticado"},
+	ErrForbidden:    {403, "Acceso denegado"},
+
 	ErrOptimisticLockFailed: {409, "Fallo de bloqueo optimista"},
 
 	ErrNetworkError: {500, "Error de red interno"},
@@ -0,0 +1,24 @@
+package logs
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type entryKey struct{}
+
+// NewContext guarda en ctx un logger con campos de la petición (request_id,
+// método, ruta) para que casos de uso y repositorios lo recuperen con FromContext.
+func NewContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryKey{}, entry)
+}
+
+// FromContext devuelve el logger de la petición, o el global si ctx no trae uno.
+// El resultado siempre lleva ctx para que trace_id y span_id reflejen el span actual.
+func FromContext(ctx context.Context) *logrus.Entry {
+	if entry, ok := ctx.Value(entryKey{}).(*logrus.Entry); ok && entry != nil {
+		return entry.WithContext(ctx)
+	}
+	return globalLogger.WithContext(ctx)
+}
@@ -1,42 +1,105 @@
 package logs
 
 import (
+	"io"
 	"os"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 
 	errors "license-service/pkg/log/error"
 )
 
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
 type Logger struct {
 	*logrus.Logger
 }
 
-var globalLogger *Logger
+// Options controla el formato, el nivel y el enmascarado de PII de todos los
+// loggers del proceso. Se aplica con Configure al arrancar.
+type Options struct {
+	Level   string
+	Format  string
+	MaskPII bool
+	// Output es stdout si es nil; licensectl escribe los logs en stderr.
+	Output io.Writer
+}
+
+var (
+	globalLogger *Logger
+	optionsMu    sync.RWMutex
+	options      = Options{Level: "info", Format: FormatText, MaskPII: true}
+)
 
 func init() {
 	globalLogger = NewLogger()
 }
 
+func Configure(opts Options) {
+	optionsMu.Lock()
+	options = opts
+	optionsMu.Unlock()
+
+	configure(globalLogger.Logger)
+}
+
 func NewLogger() *Logger {
 	log := logrus.New()
+	configure(log)
+	return &Logger{log}
+}
+
+func Global() *Logger {
+	return globalLogger
+}
 
-	log.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:   true,
-		TimestampFormat: "15:04:05",
-		ForceColors:     true,
-		DisableQuote:    true,
-		FieldMap: logrus.FieldMap{
-			logrus.FieldKeyTime:  "time",
-			logrus.FieldKeyLevel: "level",
-			logrus.FieldKeyMsg:   "msg",
-		},
-	})
-
-	log.SetOutput(os.Stdout)
-	log.SetLevel(logrus.InfoLevel)
+func configure(log *logrus.Logger) {
+	optionsMu.RLock()
+	opts := options
+	optionsMu.RUnlock()
 
-	return &Logger{log}
+	fieldMap := logrus.FieldMap{
+		logrus.FieldKeyTime:  "time",
+		logrus.FieldKeyLevel: "level",
+		logrus.FieldKeyMsg:   "msg",
+	}
+
+	if opts.Format == FormatJSON {
+		log.SetFormatter(&logrus.JSONFormatter{
+			TimestampFormat: "2006-01-02T15:04:05.000Z07:00",
+			FieldMap:        fieldMap,
+		})
+	} else {
+		log.SetFormatter(&logrus.TextFormatter{
+			FullTimestamp:   true,
+			TimestampFormat: "15:04:05",
+			ForceColors:     true,
+			DisableQuote:    true,
+			FieldMap:        fieldMap,
+		})
+	}
+
+	level, err := logrus.ParseLevel(opts.Level)
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+
+	if opts.Output != nil {
+		log.SetOutput(opts.Output)
+	} else {
+		log.SetOutput(os.Stdout)
+	}
+	log.SetLevel(level)
+
+	log.ReplaceHooks(make(logrus.LevelHooks))
+	log.AddHook(traceHook{})
+	if opts.MaskPII {
+		log.AddHook(piiHook{})
+	}
 }
 
 func (logs *Logger) LogError(component, operation string, err error, fields ...any) {
@@ -0,0 +1,52 @@
+package logs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+var rutPattern = regexp.MustCompile(`\b(\d{1,2})\d{5,6}-([\dkK])\b`)
+
+// Campos cuyo valor completo se considera dato sensible.
+var redactedFields = map[string]bool{
+	"diagnosis": true,
+}
+
+// piiHook enmascara RUTs en el mensaje y en los campos, y oculta por completo los
+// campos de diagnóstico.
+type piiHook struct{}
+
+func (piiHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (piiHook) Fire(entry *logrus.Entry) error {
+	entry.Message = MaskRuts(entry.Message)
+
+	for key, value := range entry.Data {
+		if redactedFields[strings.ToLower(key)] {
+			entry.Data[key] = "[REDACTED]"
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			entry.Data[key] = MaskRuts(v)
+		case fmt.Stringer:
+			entry.Data[key] = MaskRuts(v.String())
+		}
+	}
+	return nil
+}
+
+// MaskRuts reemplaza cada RUT por sus dos primeros dígitos y el dígito verificador:
+// 12345678-9 -> 12******-9.
+func MaskRuts(value string) string {
+	return rutPattern.ReplaceAllStringFunc(value, func(rut string) string {
+		match := rutPattern.FindStringSubmatch(rut)
+		hidden := len(rut) - len(match[1]) - 2
+		return match[1] + strings.Repeat("*", hidden) + "-" + match[2]
+	})
+}
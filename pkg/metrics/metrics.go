@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	errors "license-service/pkg/log/error"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "licenses"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by mux route template, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by mux route template, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	UseCaseExecutionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "usecase_executions_total",
+		Help:      "Use case executions by use case, result and AppError code.",
+	}, []string{"usecase", "result", "code"})
+
+	LicensesIssuedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "issued_total",
+		Help:      "Licenses issued.",
+	})
+
+	LicensesImportedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "imported_total",
+		Help:      "Licenses created by bulk imports.",
+	})
+
+	LicensesExportedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "exported_total",
+		Help:      "Licenses written by bulk exports, by format.",
+	}, []string{"format"})
+
+	LicensesRevokedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "revoked_total",
+		Help:      "Licenses revoked.",
+	})
+
+	LicensesExpiredTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "expired_total",
+		Help:      "Licenses marked as expired.",
+	})
+
+	LicensesAdjudicatedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "adjudicated_total",
+		Help:      "Licenses resolved by the insurer, by resolution (approved, reduced, rejected).",
+	}, []string{"resolution"})
+
+	LicenseVerificationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "verifications_total",
+		Help:      "License verifications by reason (valid, pending_review, not_started, expired, revoked, rejected, not_found).",
+	}, []string{"reason"})
+
+	CacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Cache lookups by cache name and result (hit, negative_hit, miss, error).",
+	}, []string{"cache", "result"})
+
+	CircuitBreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "circuit_breaker_state",
+		Help:      "Circuit breaker state by name: 0 closed, 1 open, 2 half-open.",
+	}, []string{"name"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		UseCaseExecutionsTotal,
+		LicensesIssuedTotal,
+		LicensesImportedTotal,
+		LicensesExportedTotal,
+		LicensesRevokedTotal,
+		LicensesExpiredTotal,
+		LicensesAdjudicatedTotal,
+		LicenseVerificationsTotal,
+		CacheRequestsTotal,
+		CircuitBreakerState,
+	)
+}
+
+// Register permite que otros subsistemas expongan sus propios collectors en /metrics.
+func Register(collector prometheus.Collector) error {
+	return registry.Register(collector)
+}
+
+// RegisterDBStats expone open/in-use/idle/wait count del pool de conexiones.
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	return registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// ObserveUseCase cuenta una ejecución de caso de uso. Los errores se etiquetan con
+// el código de AppError para distinguir fallos de validación de fallos de infraestructura.
+func ObserveUseCase(usecase string, err error) {
+	result, code := "success", "OK"
+	if err != nil {
+		result, code = "failure", string(errors.ErrUnknownError)
+		if appErr, ok := err.(*errors.AppError); ok {
+			code = string(appErr.Code)
+		}
+	}
+	UseCaseExecutionsTotal.WithLabelValues(usecase, result, code).Inc()
+}
@@ -0,0 +1,30 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit describe un token bucket: Rate fichas por segundo hasta un máximo de Burst.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+func PerMinute(requests int, burst int) Limit {
+	return Limit{Rate: float64(requests) / 60, Burst: burst}
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// Store guarda los buckets por clave. MemoryStore sirve para una sola instancia;
+// con varias réplicas se necesita una implementación compartida (p. ej. Redis).
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
@@ -0,0 +1,130 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+
+	env "license-service/pkg/env"
+	errorInfo "license-service/pkg/log/error"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+
+	instrumentationName = "license-service"
+)
+
+func init() {
+	// El propagador W3C se instala siempre: aun con el tracing deshabilitado el
+	// traceparent entrante se respeta y se reenvía.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+// SetupTracing instala el TracerProvider global con el exportador configurado y
+// devuelve la función que vacía y cierra el exportador durante el apagado.
+func SetupTracing(ctx context.Context, config env.TracingConfig) (func(context.Context) error, error) {
+	if !config.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closeOutput, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			attribute.String("service.name", config.ServiceName),
+			attribute.String("deployment.environment", config.Environment),
+		),
+	)
+	if err != nil {
+		return nil, errorInfo.WrapError(errorInfo.ErrServiceInit, "Telemetry", "SetupTracing", "failed to build trace resource", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closeOutput != nil {
+			closeOutput()
+		}
+		return err
+	}, nil
+}
+
+func newExporter(ctx context.Context, config env.TracingConfig) (sdktrace.SpanExporter, func(), error) {
+	switch config.Exporter {
+	case ExporterOTLP:
+		options := []otlptracehttp.Option{}
+		if config.OTLPEndpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(config.OTLPEndpoint))
+		}
+		exporter, err := otlptracehttp.New(ctx, options...)
+		if err != nil {
+			return nil, nil, errorInfo.WrapError(errorInfo.ErrServiceInit, "Telemetry", "newExporter", "failed to create OTLP exporter", err)
+		}
+		return exporter, nil, nil
+	case ExporterFile:
+		file, err := os.OpenFile(config.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, nil, errorInfo.WrapError(errorInfo.ErrServiceInit, "Telemetry", "newExporter", "failed to open trace file", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, errorInfo.WrapError(errorInfo.ErrServiceInit, "Telemetry", "newExporter", "failed to create file exporter", err)
+		}
+		return exporter, func() { file.Close() }, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, nil, errorInfo.WrapError(errorInfo.ErrServiceInit, "Telemetry", "newExporter", "failed to create stdout exporter", err)
+		}
+		return exporter, nil, nil
+	default:
+		return nil, nil, errorInfo.NewAppError(errorInfo.ErrServiceConfig, "Telemetry", "newExporter", "unknown TRACING_EXPORTER: "+config.Exporter)
+	}
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// EndSpan marca el span como fallido si err no es nil y lo cierra.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if appErr, ok := err.(*errorInfo.AppError); ok {
+			span.SetAttributes(attribute.String("app.error_code", string(appErr.Code)))
+		}
+	}
+	span.End()
+}